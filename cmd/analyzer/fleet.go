@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"ingress-migration-analyzer/pkg/analyze"
+	"ingress-migration-analyzer/pkg/report"
+)
+
+// runFleet analyzes every selected kubeconfig context and writes a single
+// fleet report. With an inventory generator, each cluster analysis also
+// carries its annotation inventory and markdown reports use its layout.
+func runFleet(inventory *InventoryMarkdownGenerator) error {
+	contexts, err := fleetContexts()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n🌐 Analyzing %d clusters (up to %d at a time)...\n", len(contexts), analyze.DefaultFleetConcurrency)
+	fleet := analyze.AnalyzeFleet(context.Background(), contexts, newClusterAnalyzer, analyze.DefaultFleetConcurrency)
+	if fleet.Summary.FailedClusters == len(contexts) {
+		return fmt.Errorf("analysis failed: none of the %d clusters could be analyzed", len(contexts))
+	}
+
+	if inventory != nil {
+		for _, cluster := range fleet.Clusters {
+			if cluster.Analysis != nil {
+				cluster.Analysis.Inventory = analyze.BuildAnnotationInventory(cluster.Analysis.Analyses)
+			}
+		}
+	}
+
+	// Generate report
+	fmt.Println("\n📝 Generating fleet report...")
+	var reportPath string
+
+	switch format {
+	case "markdown":
+		if inventory != nil {
+			reportPath, err = inventory.GenerateFleetInventoryReport(fleet, output)
+			break
+		}
+		generator := report.NewMarkdownGenerator()
+		reportPath, err = generator.GenerateFleetReport(fleet, output)
+	case "json":
+		generator := report.NewJSONGenerator()
+		reportPath, err = generator.GenerateFleetReport(fleet, output)
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to generate fleet report: %w", err)
+	}
+
+	fmt.Printf("✅ Fleet analysis complete! Report saved to: %s\n", reportPath)
+
+	if fleet.Summary.FailedClusters > 0 {
+		fmt.Printf("\n⚠️  Warning: %d of %d clusters could not be analyzed\n", fleet.Summary.FailedClusters, len(contexts))
+	}
+	if fleet.Summary.HighRiskCount > 0 {
+		fmt.Printf("\n⚠️  Warning: Found %d high-risk resources across the fleet requiring careful migration planning\n",
+			fleet.Summary.HighRiskCount)
+	}
+
+	return nil
+}
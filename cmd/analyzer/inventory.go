@@ -14,6 +14,7 @@ import (
 	"ingress-migration-analyzer/pkg/analyze"
 	"ingress-migration-analyzer/pkg/common"
 	"ingress-migration-analyzer/pkg/report"
+	"ingress-migration-analyzer/pkg/rules"
 )
 
 var inventoryCmd = &cobra.Command{
@@ -43,6 +44,7 @@ func init() {
 	inventoryCmd.Flags().IntP("top", "t", 10, "Show top N most used annotations")
 	inventoryCmd.Flags().StringVar(&output, "output", "./reports/", "Output directory for reports")
 	inventoryCmd.Flags().StringVar(&format, "format", "json", "Output format (json recommended for inventory data)")
+	addSourceFlags(inventoryCmd)
 }
 
 func runInventory(cmd *cobra.Command, args []string) error {
@@ -56,27 +58,29 @@ func runInventory(cmd *cobra.Command, args []string) error {
 	fmt.Printf("📊 Sort by: %s\n", sortBy)
 	fmt.Printf("🔝 Top N: %d\n", topN)
 
-	if kubeconfig != "" {
-		fmt.Printf("🔧 Kubeconfig: %s\n", kubeconfig)
-	}
-	if contextName != "" {
-		fmt.Printf("🎯 Context: %s\n", contextName)
-	}
+	printSource()
 
 	// Validate flags
 	if err := validateFlags(); err != nil {
 		return fmt.Errorf("validation error: %w", err)
 	}
 
-	// Create Kubernetes client with validation
-	fmt.Println("\n🔌 Testing Kubernetes connection...")
-	client, err := common.CreateAnalyzerClient(kubeconfig, contextName)
-	if err != nil {
-		return err
+	generator := &InventoryMarkdownGenerator{
+		Detailed:    detailed,
+		SortBy:      sortBy,
+		TopN:        topN,
+		ContextName: contextName,
+	}
+
+	if isFleet() {
+		return runFleet(generator)
 	}
 
 	// Create analyzer and run analysis
-	analyzer := analyze.NewAnalyzer(client, namespace)
+	analyzer, err := newAnalyzer()
+	if err != nil {
+		return err
+	}
 	clusterAnalysis, err := analyzer.AnalyzeCluster(context.Background())
 	if err != nil {
 		return fmt.Errorf("analysis failed: %w", err)
@@ -98,17 +102,10 @@ func runInventory(cmd *cobra.Command, args []string) error {
 
 	switch format {
 	case "markdown":
-		generator := &InventoryMarkdownGenerator{
-			Detailed:    detailed,
-			SortBy:      sortBy,
-			TopN:        topN,
-			ContextName: contextName,
-		}
 		reportPath, err = generator.GenerateInventoryReport(inventory, clusterAnalysis, output)
 	case "json":
 		// JSON includes full inventory data automatically
-		generator := report.NewJSONGenerator()
-		reportPath, err = generator.GenerateReport(clusterAnalysis, output)
+		reportPath, err = report.NewJSONGenerator().GenerateReport(clusterAnalysis, output)
 	default:
 		return fmt.Errorf("unsupported format: %s", format)
 	}
@@ -122,7 +119,7 @@ func runInventory(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func printInventorySummary(inventory *analyze.AnnotationInventory, topN int) {
+func printInventorySummary(inventory *models.AnnotationInventory, topN int) {
 	fmt.Println("\n📈 Annotation Inventory Summary:")
 	fmt.Printf("   Total Unique Annotations: %d\n", inventory.Summary.TotalUniqueAnnotations)
 	fmt.Printf("   Nginx Annotations: %d\n", inventory.Summary.NginxAnnotationsCount)
@@ -133,7 +130,7 @@ func printInventorySummary(inventory *analyze.AnnotationInventory, topN int) {
 	}
 
 	// Show most critical annotations
-	critical := inventory.GetMostCriticalAnnotations(topN)
+	critical := analyze.GetMostCriticalAnnotations(inventory, topN)
 	if len(critical) > 0 {
 		fmt.Println("\n🚨 Most Critical Annotations (for migration):")
 		for i, annotation := range critical {
@@ -146,7 +143,7 @@ func printInventorySummary(inventory *analyze.AnnotationInventory, topN int) {
 	}
 
 	// Show annotations by risk
-	byRisk := inventory.GetAnnotationsByRisk()
+	byRisk := analyze.GetAnnotationsByRisk(inventory)
 	if len(byRisk) > 0 {
 		fmt.Println("\n📊 Annotations by Risk Level:")
 		for riskLevel, annotations := range byRisk {
@@ -163,7 +160,7 @@ type InventoryMarkdownGenerator struct {
 	ContextName string
 }
 
-func (g *InventoryMarkdownGenerator) GenerateInventoryReport(inventory *analyze.AnnotationInventory, analysis *models.ClusterAnalysis, outputDir string) (string, error) {
+func (g *InventoryMarkdownGenerator) GenerateInventoryReport(inventory *models.AnnotationInventory, analysis *models.ClusterAnalysis, outputDir string) (string, error) {
 	// Generate the inventory report content
 	content := g.generateInventoryContent(inventory, analysis)
 
@@ -181,8 +178,90 @@ func (g *InventoryMarkdownGenerator) GenerateInventoryReport(inventory *analyze.
 	return filePath, nil
 }
 
+// GenerateFleetInventoryReport creates one inventory report with the
+// annotation usage across the fleet followed by the inventory of each cluster
+func (g *InventoryMarkdownGenerator) GenerateFleetInventoryReport(fleet *models.FleetAnalysis, outputDir string) (string, error) {
+	var content strings.Builder
+
+	content.WriteString("# NGINX Ingress Fleet Annotation Inventory Report\n\n")
+	content.WriteString(fmt.Sprintf("**Generated**: %s\n", fleet.ScanTime.Format("2006-01-02 15:04:05")))
+	content.WriteString(fmt.Sprintf("**Clusters**: %d", fleet.Summary.Clusters))
+	if fleet.Summary.FailedClusters > 0 {
+		content.WriteString(fmt.Sprintf(" (%d could not be analyzed)", fleet.Summary.FailedClusters))
+	}
+	content.WriteString("\n")
+	content.WriteString(fmt.Sprintf("**Total Ingress Resources Scanned**: %d\n", fleet.Summary.TotalIngresses))
+	content.WriteString("\n---\n\n")
+
+	g.writeFleetAnnotations(&content, fleet)
+
+	for _, cluster := range fleet.Clusters {
+		content.WriteString(fmt.Sprintf("## Cluster: %s\n\n", cluster.Context))
+		if cluster.Analysis == nil || cluster.Analysis.Inventory == nil {
+			content.WriteString(fmt.Sprintf("❌ Could not be analyzed: %s\n\n", cluster.Error))
+			continue
+		}
+
+		// Reuse the single-cluster inventory, nested two heading levels down
+		clusterReport := *g
+		clusterReport.ContextName = cluster.Context
+		content.WriteString(report.DemoteHeadings(clusterReport.generateInventoryContent(cluster.Analysis.Inventory, cluster.Analysis), 2))
+		content.WriteString("\n")
+	}
+
+	timestamp := time.Now().Format("2006-01-02-150405")
+	filename := fmt.Sprintf("fleet-annotation-inventory-%s.md", timestamp)
+	filePath := filepath.Join(outputDir, filename)
+
+	if err := os.WriteFile(filePath, []byte(content.String()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write inventory report: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// writeFleetAnnotations writes the annotation usage across the fleet, in
+// the report's sort order and limited to its top N annotations
+func (g *InventoryMarkdownGenerator) writeFleetAnnotations(content *strings.Builder, fleet *models.FleetAnalysis) {
+	content.WriteString("## Annotation Usage Across the Fleet\n\n")
+	if len(fleet.Summary.Annotations) == 0 {
+		content.WriteString("🎉 **No NGINX annotations found in any cluster!**\n\n---\n\n")
+		return
+	}
+
+	annotations := make([]models.FleetAnnotationUsage, len(fleet.Summary.Annotations))
+	copy(annotations, fleet.Summary.Annotations)
+	switch g.SortBy {
+	case "risk":
+		sort.SliceStable(annotations, func(i, j int) bool {
+			return rules.HigherRisk(annotations[i].Risk, annotations[j].Risk)
+		})
+	case "name":
+		sort.SliceStable(annotations, func(i, j int) bool {
+			return annotations[i].Key < annotations[j].Key
+		})
+	}
+	// Otherwise keep the summary order, most used first
+
+	content.WriteString("| Annotation | Risk Level | Usage Count | Clusters |\n")
+	content.WriteString("|------------|------------|-------------|----------|\n")
+	for i, annotation := range annotations {
+		if i >= g.TopN {
+			break
+		}
+		content.WriteString(fmt.Sprintf("| `%s` | %s %s | %d | %d (%s) |\n",
+			annotation.Key, g.getRiskIcon(annotation.Risk), annotation.Risk,
+			annotation.UsageCount, len(annotation.Clusters), strings.Join(annotation.Clusters, ", ")))
+	}
+	if len(annotations) > g.TopN {
+		content.WriteString(fmt.Sprintf("\n*Showing the top %d of %d annotations; use `--top` to show more.*\n", g.TopN, len(annotations)))
+	}
+
+	content.WriteString("\n---\n\n")
+}
+
 // generateInventoryContent creates the comprehensive inventory markdown content
-func (g *InventoryMarkdownGenerator) generateInventoryContent(inventory *analyze.AnnotationInventory, analysis *models.ClusterAnalysis) string {
+func (g *InventoryMarkdownGenerator) generateInventoryContent(inventory *models.AnnotationInventory, analysis *models.ClusterAnalysis) string {
 	var content strings.Builder
 
 	// Header
@@ -217,13 +296,17 @@ func (g *InventoryMarkdownGenerator) generateInventoryContent(inventory *analyze
 }
 
 // writeInventoryHeader writes the report header
-func (g *InventoryMarkdownGenerator) writeInventoryHeader(content *strings.Builder, inventory *analyze.AnnotationInventory, analysis *models.ClusterAnalysis) {
+func (g *InventoryMarkdownGenerator) writeInventoryHeader(content *strings.Builder, inventory *models.AnnotationInventory, analysis *models.ClusterAnalysis) {
 	content.WriteString("# NGINX Ingress Annotation Inventory Report\n\n")
 	content.WriteString(fmt.Sprintf("**Generated**: %s\n", time.Now().Format("2006-01-02 15:04:05")))
 	if g.ContextName != "" {
 		content.WriteString(fmt.Sprintf("**Cluster Context**: %s\n", g.ContextName))
 	}
-	content.WriteString(fmt.Sprintf("**Cluster Version**: %s\n", analysis.ScanResult.ClusterVersion))
+	if analysis.ScanResult.Source != "" {
+		content.WriteString(fmt.Sprintf("**Source**: %s\n", analysis.ScanResult.Source))
+	} else {
+		content.WriteString(fmt.Sprintf("**Cluster Version**: %s\n", analysis.ScanResult.ClusterVersion))
+	}
 	content.WriteString(fmt.Sprintf("**Total Ingress Resources Scanned**: %d\n", analysis.ScanResult.TotalIngresses))
 	content.WriteString(fmt.Sprintf("**Total Unique Annotations Found**: %d\n", inventory.Summary.TotalUniqueAnnotations))
 	content.WriteString(fmt.Sprintf("**NGINX Annotations**: %d\n", inventory.Summary.NginxAnnotationsCount))
@@ -231,7 +314,7 @@ func (g *InventoryMarkdownGenerator) writeInventoryHeader(content *strings.Build
 }
 
 // writeInventorySummary writes the executive summary
-func (g *InventoryMarkdownGenerator) writeInventorySummary(content *strings.Builder, inventory *analyze.AnnotationInventory) {
+func (g *InventoryMarkdownGenerator) writeInventorySummary(content *strings.Builder, inventory *models.AnnotationInventory) {
 	content.WriteString("## Executive Summary\n\n")
 	
 	if inventory.Summary.TotalUniqueAnnotations == 0 {
@@ -259,8 +342,8 @@ func (g *InventoryMarkdownGenerator) writeInventorySummary(content *strings.Buil
 }
 
 // writeCriticalAnnotations highlights the most critical annotations for migration
-func (g *InventoryMarkdownGenerator) writeCriticalAnnotations(content *strings.Builder, inventory *analyze.AnnotationInventory) {
-	critical := inventory.GetMostCriticalAnnotations(g.TopN)
+func (g *InventoryMarkdownGenerator) writeCriticalAnnotations(content *strings.Builder, inventory *models.AnnotationInventory) {
+	critical := analyze.GetMostCriticalAnnotations(inventory, g.TopN)
 	
 	if len(critical) == 0 {
 		content.WriteString("## Critical Annotations\n\n")
@@ -289,10 +372,10 @@ func (g *InventoryMarkdownGenerator) writeCriticalAnnotations(content *strings.B
 }
 
 // writeAnnotationsByRisk groups annotations by risk level
-func (g *InventoryMarkdownGenerator) writeAnnotationsByRisk(content *strings.Builder, inventory *analyze.AnnotationInventory) {
+func (g *InventoryMarkdownGenerator) writeAnnotationsByRisk(content *strings.Builder, inventory *models.AnnotationInventory) {
 	content.WriteString("## Annotations by Migration Risk Level\n\n")
 
-	byRisk := inventory.GetAnnotationsByRisk()
+	byRisk := analyze.GetAnnotationsByRisk(inventory)
 	
 	// Process each risk level
 	riskLevels := []models.RiskLevel{models.RiskAuto, models.RiskManual, models.RiskHigh}
@@ -330,12 +413,12 @@ func (g *InventoryMarkdownGenerator) writeAnnotationsByRisk(content *strings.Bui
 }
 
 // writeUnknownAnnotations details unknown annotations that need research
-func (g *InventoryMarkdownGenerator) writeUnknownAnnotations(content *strings.Builder, inventory *analyze.AnnotationInventory) {
+func (g *InventoryMarkdownGenerator) writeUnknownAnnotations(content *strings.Builder, inventory *models.AnnotationInventory) {
 	content.WriteString("## Unknown NGINX Annotations\n\n")
 	content.WriteString("These annotations are not in our knowledge base and require manual research:\n\n")
 
 	// Convert to slice for sorting
-	var unknownList []*analyze.AnnotationUsage
+	var unknownList []*models.AnnotationUsage
 	for _, usage := range inventory.UnknownAnnotations {
 		unknownList = append(unknownList, usage)
 	}
@@ -356,11 +439,11 @@ func (g *InventoryMarkdownGenerator) writeUnknownAnnotations(content *strings.Bu
 }
 
 // writeDetailedUsage provides comprehensive usage analysis
-func (g *InventoryMarkdownGenerator) writeDetailedUsage(content *strings.Builder, inventory *analyze.AnnotationInventory) {
+func (g *InventoryMarkdownGenerator) writeDetailedUsage(content *strings.Builder, inventory *models.AnnotationInventory) {
 	content.WriteString("## Detailed Usage Analysis\n\n")
 
 	// Convert nginx annotations to slice for sorting
-	var allNginx []*analyze.AnnotationUsage
+	var allNginx []*models.AnnotationUsage
 	for _, usage := range inventory.NginxAnnotations {
 		allNginx = append(allNginx, usage)
 	}
@@ -418,10 +501,10 @@ func (g *InventoryMarkdownGenerator) writeDetailedUsage(content *strings.Builder
 }
 
 // writeMigrationStrategies provides strategic guidance
-func (g *InventoryMarkdownGenerator) writeMigrationStrategies(content *strings.Builder, inventory *analyze.AnnotationInventory) {
+func (g *InventoryMarkdownGenerator) writeMigrationStrategies(content *strings.Builder, inventory *models.AnnotationInventory) {
 	content.WriteString("## Migration Strategy Recommendations\n\n")
 
-	byRisk := inventory.GetAnnotationsByRisk()
+	byRisk := analyze.GetAnnotationsByRisk(inventory)
 	
 	content.WriteString("### Phase 1: Auto-Migratable (Low Risk)\n")
 	if autoAnnotations := byRisk[models.RiskAuto]; len(autoAnnotations) > 0 {
@@ -505,7 +588,7 @@ func (g *InventoryMarkdownGenerator) getRiskIcon(risk models.RiskLevel) string {
 	}
 }
 
-func (g *InventoryMarkdownGenerator) sortAnnotations(annotations []*analyze.AnnotationUsage, sortBy string) {
+func (g *InventoryMarkdownGenerator) sortAnnotations(annotations []*models.AnnotationUsage, sortBy string) {
 	switch sortBy {
 	case "usage":
 		sort.Slice(annotations, func(i, j int) bool {
@@ -566,14 +649,14 @@ func (g *InventoryMarkdownGenerator) formatValueExamples(valueExamples map[strin
 	return result
 }
 
-func (g *InventoryMarkdownGenerator) getTopAnnotations(annotations []*analyze.AnnotationUsage, limit int) []*analyze.AnnotationUsage {
+func (g *InventoryMarkdownGenerator) getTopAnnotations(annotations []*models.AnnotationUsage, limit int) []*models.AnnotationUsage {
 	if len(annotations) <= limit {
 		return annotations
 	}
 	return annotations[:limit]
 }
 
-func (g *InventoryMarkdownGenerator) analyzeNamespaceComplexity(inventory *analyze.AnnotationInventory) map[string]string {
+func (g *InventoryMarkdownGenerator) analyzeNamespaceComplexity(inventory *models.AnnotationInventory) map[string]string {
 	namespaceComplexity := make(map[string]string)
 	
 	// Analyze each namespace by looking at annotation usage
@@ -4,21 +4,24 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/spf13/cobra"
-	"ingress-migration-analyzer/pkg/analyze"
-	"ingress-migration-analyzer/pkg/common"
 	"ingress-migration-analyzer/pkg/report"
+	"ingress-migration-analyzer/pkg/rules"
 )
 
 var (
 	version = "0.1.0"
 	kubeconfig string
 	contextName string
+	impersonateUser string
+	impersonateGroups []string
+	bearerToken string
+	apiServer string
 	namespace string
 	output string
 	format string
+	watch bool
 )
 
 var rootCmd = &cobra.Command{
@@ -38,7 +41,8 @@ var scanCmd = &cobra.Command{
 a migration complexity analysis report.
 
 This command will:
-- Connect to your Kubernetes cluster
+- Connect to your Kubernetes cluster (or read manifests with --from-file/--dir,
+  or render a Helm chart with --helm-chart, or build overlays with --kustomize)
 - Discover all ingress-nginx resources
 - Analyze annotation complexity
 - Generate a detailed migration report`,
@@ -47,36 +51,38 @@ This command will:
 
 func init() {
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", getDefaultKubeconfig(), "Path to kubeconfig file")
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config, in-cluster config when running in a Pod)")
 	rootCmd.PersistentFlags().StringVar(&contextName, "context", "", "Kubernetes context to use")
+	rootCmd.PersistentFlags().StringVar(&impersonateUser, "as", "", "Username to impersonate, e.g. system:serviceaccount:team-x:deployer (applies to every context with --all-contexts/--contexts)")
+	rootCmd.PersistentFlags().StringArrayVar(&impersonateGroups, "as-group", nil, "Group to impersonate (repeatable; applies to every context with --all-contexts/--contexts)")
+	rootCmd.PersistentFlags().StringVar(&bearerToken, "token", "", "Bearer token for authentication to the API server (single cluster only)")
+	rootCmd.PersistentFlags().StringVar(&apiServer, "server", "", "Address of the Kubernetes API server (single cluster only)")
 	rootCmd.PersistentFlags().StringVar(&namespace, "namespace", "", "Specific namespace to scan (default: all namespaces)")
 
 	// Scan command flags
 	scanCmd.Flags().StringVar(&output, "output", "./reports/", "Output directory for reports")
 	scanCmd.Flags().StringVar(&format, "format", "markdown", "Output format (markdown|json)")
+	scanCmd.Flags().BoolVar(&watch, "watch", false, "Keep watching the cluster and print risk changes as Ingresses and controller ConfigMaps change (with --format json, JSON lines on stdout and everything else on stderr)")
+	addSourceFlags(scanCmd)
 
 	rootCmd.AddCommand(scanCmd)
 	rootCmd.AddCommand(inventoryCmd)
+	rootCmd.AddCommand(preflightCmd)
 }
 
-func getDefaultKubeconfig() string {
-	if home, err := os.UserHomeDir(); err == nil {
-		return filepath.Join(home, ".kube", "config")
+func runScan(cmd *cobra.Command, args []string) error {
+	// In JSON watch mode stdout carries only the JSON lines of the changes;
+	// banners, summaries and warnings go to stderr
+	deltas := os.Stdout
+	if watch && format == "json" {
+		os.Stdout = os.Stderr
 	}
-	return ""
-}
 
-func runScan(cmd *cobra.Command, args []string) error {
 	fmt.Printf("🔍 Starting ingress-nginx migration analysis...\n")
 	fmt.Printf("📁 Output directory: %s\n", output)
 	fmt.Printf("📄 Format: %s\n", format)
 	
-	if kubeconfig != "" {
-		fmt.Printf("🔧 Kubeconfig: %s\n", kubeconfig)
-	}
-	if contextName != "" {
-		fmt.Printf("🎯 Context: %s\n", contextName)
-	}
+	printSource()
 	if namespace != "" {
 		fmt.Printf("📦 Namespace: %s\n", namespace)
 	} else {
@@ -88,15 +94,18 @@ func runScan(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("validation error: %w", err)
 	}
 
-	// Create Kubernetes client with validation
-	fmt.Println("\n🔌 Testing Kubernetes connection...")
-	client, err := common.CreateAnalyzerClient(kubeconfig, contextName)
-	if err != nil {
-		return err
+	if isFleet() {
+		return runFleet(nil)
+	}
+	if watch {
+		return runWatch(deltas)
 	}
 
 	// Create analyzer and run analysis
-	analyzer := analyze.NewAnalyzer(client, namespace)
+	analyzer, err := newAnalyzer()
+	if err != nil {
+		return err
+	}
 	clusterAnalysis, err := analyzer.AnalyzeCluster(context.Background())
 	if err != nil {
 		return fmt.Errorf("analysis failed: %w", err)
@@ -133,13 +142,50 @@ func runScan(cmd *cobra.Command, args []string) error {
 }
 
 func validateFlags() error {
-	// Check if kubeconfig file exists
-	if kubeconfig != "" {
+	// Check if kubeconfig file exists (not needed when reading manifests)
+	if kubeconfig != "" && !isOffline() {
 		if _, err := os.Stat(kubeconfig); os.IsNotExist(err) {
 			return fmt.Errorf("kubeconfig file not found: %s", kubeconfig)
 		}
 	}
 
+	if len(helmValues) > 0 && helmChart == "" {
+		return fmt.Errorf("--values requires --helm-chart")
+	}
+
+	if namespaceSelector != "" && isOffline() {
+		return fmt.Errorf("--namespace-selector needs cluster access to read namespace labels")
+	}
+	if err := scanOptions().Validate(); err != nil {
+		return err
+	}
+	if err := rules.LoadRuleFiles(ruleFiles); err != nil {
+		return err
+	}
+
+	if isFleet() {
+		if isOffline() {
+			return fmt.Errorf("--all-contexts and --contexts cannot be combined with offline inputs")
+		}
+		if contextName != "" {
+			return fmt.Errorf("--context cannot be combined with --all-contexts or --contexts")
+		}
+		if allContexts && len(kubeContexts) > 0 {
+			return fmt.Errorf("--all-contexts and --contexts are mutually exclusive")
+		}
+		if apiServer != "" {
+			return fmt.Errorf("--server cannot be combined with --all-contexts or --contexts")
+		}
+		// A token is a credential for one cluster and must not be sent to the others
+		if bearerToken != "" {
+			return fmt.Errorf("--token cannot be combined with --all-contexts or --contexts")
+		}
+	}
+
+	if watch && (isOffline() || isFleet()) {
+		return fmt.Errorf("--watch needs a single cluster and cannot be combined with offline inputs or multiple contexts")
+	}
+
 	// Validate output format
 	if format != "markdown" && format != "json" {
 		return fmt.Errorf("invalid format '%s': must be 'markdown' or 'json'", format)
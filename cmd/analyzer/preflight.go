@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"ingress-migration-analyzer/pkg/discovery"
+)
+
+// clusterRoleName is the name of the ClusterRole emitted by preflight
+const clusterRoleName = "ingress-migration-analyzer"
+
+var emitClusterRole bool
+
+var preflightCmd = &cobra.Command{
+	Use:   "preflight",
+	Short: "Check the RBAC permissions the analyzer needs",
+	Long: `Check every Kubernetes permission the analyzer may use with
+SelfSubjectAccessReviews and print which ones are allowed and which
+features will be degraded without them.
+
+When listing Ingresses across all namespaces is denied, a
+SelfSubjectRulesReview per namespace shows where the per-namespace
+fallback can still list them.
+
+With --emit-clusterrole, print a minimal ClusterRole granting every
+permission instead, for example:
+
+  analyzer preflight --emit-clusterrole | kubectl apply -f -`,
+	RunE: runPreflight,
+}
+
+func init() {
+	preflightCmd.Flags().BoolVar(&emitClusterRole, "emit-clusterrole", false, "Print a minimal ClusterRole YAML for the analyzer instead of checking permissions")
+}
+
+func runPreflight(cmd *cobra.Command, args []string) error {
+	if emitClusterRole {
+		fmt.Print(discovery.ClusterRoleYAML(clusterRoleName))
+		return nil
+	}
+
+	fmt.Printf("🛂 Checking RBAC permissions...\n")
+	printSource()
+	if namespace != "" {
+		fmt.Printf("📦 Namespace: %s\n", namespace)
+	} else {
+		fmt.Printf("📦 All namespaces\n")
+	}
+
+	if kubeconfig != "" {
+		if _, err := os.Stat(kubeconfig); os.IsNotExist(err) {
+			return fmt.Errorf("validation error: kubeconfig file not found: %s", kubeconfig)
+		}
+	}
+
+	fmt.Println("\n🔌 Testing Kubernetes connection...")
+	client, err := discovery.NewClient(kubeconfig, contextName, clientOptions())
+	if err != nil {
+		return fmt.Errorf("connection failed: %w", err)
+	}
+	fmt.Printf("✅ Connected to cluster (version: %s)\n\n", client.ClusterVersion)
+
+	ctx := context.Background()
+	checks, err := discovery.CheckPermissions(ctx, client, namespace)
+	if err != nil {
+		return err
+	}
+	printPermissionMatrix(checks)
+	for _, check := range checks {
+		if check.Exposure != "" {
+			fmt.Printf("\n🔑 %s exposes %s; it is only used by %s\n", check.Permission, check.Exposure, check.Feature)
+		}
+	}
+
+	// Explain how far the per-namespace fallback gets
+	if namespace == "" && !permissionAllowed(checks, "ingresses", "list") && permissionAllowed(checks, "namespaces", "list") {
+		allowed, total, err := discovery.IngressListableNamespaces(ctx, client)
+		if err != nil {
+			fmt.Printf("\n⚠️  Warning: %v\n", err)
+		} else {
+			fmt.Printf("\n📂 Per-namespace fallback can list Ingresses in %d of %d namespaces", len(allowed), total)
+			if len(allowed) > 0 {
+				fmt.Printf(": %s", strings.Join(allowed, ", "))
+			}
+			fmt.Println()
+		}
+	}
+
+	degraded := discovery.DegradedFeatures(checks)
+	if len(degraded) == 0 {
+		fmt.Println("\n✅ All permissions granted; every feature is available")
+		return nil
+	}
+
+	fmt.Println("\n⚠️  Degraded features:")
+	for _, feature := range degraded {
+		fmt.Printf("   - %s\n", feature)
+	}
+	fmt.Printf("\n💡 Grant the missing permissions with: analyzer preflight --emit-clusterrole\n")
+
+	return nil
+}
+
+// printPermissionMatrix prints one row per permission check
+func printPermissionMatrix(checks []discovery.PermissionCheck) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PERMISSION\tSCOPE\tALLOWED\tFEATURE")
+	for _, check := range checks {
+		scope := "cluster"
+		if check.Namespaced {
+			scope = "all namespaces"
+			if check.Namespace != "" {
+				scope = check.Namespace
+			}
+		}
+		allowed := "✅ yes"
+		if !check.Allowed {
+			allowed = "❌ no"
+		}
+		feature := check.Feature
+		if check.Required {
+			feature += " (required)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", check.Permission, scope, allowed, feature)
+	}
+	w.Flush()
+}
+
+// permissionAllowed reports whether the check for verb on resource passed
+func permissionAllowed(checks []discovery.PermissionCheck, resource, verb string) bool {
+	for _, check := range checks {
+		if check.Resource == resource && check.Verb == verb {
+			return check.Allowed
+		}
+	}
+	return false
+}
+
+// checkScanPermissions warns about features a scan will skip for lack of
+// RBAC permissions, and fails early when Ingresses cannot be listed at all
+func checkScanPermissions(client *discovery.Client) error {
+	checks, err := discovery.CheckPermissions(context.Background(), client, namespace)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v; skipping permission checks\n", err)
+		return nil
+	}
+
+	var relevant []discovery.PermissionCheck
+	for _, check := range checks {
+		// Watch permissions only matter for scan --watch, Secrets for --inspect-certificates
+		if check.Feature == discovery.FeatureWatch && !watch {
+			continue
+		}
+		if check.Feature == discovery.FeatureCertificates && !inspectCertificates {
+			continue
+		}
+		relevant = append(relevant, check)
+	}
+
+	if !permissionAllowed(relevant, "ingresses", "list") {
+		if namespace != "" || !permissionAllowed(relevant, "namespaces", "list") {
+			return fmt.Errorf("missing permission to list ingresses; run 'analyzer preflight' for details")
+		}
+		fmt.Println("⚠️  Listing Ingresses across all namespaces is denied; namespaces will be scanned one by one")
+	}
+
+	if degraded := discovery.DegradedFeatures(relevant); len(degraded) > 0 {
+		fmt.Printf("⚠️  Missing RBAC permissions degrade: %s (run 'analyzer preflight' for details)\n", strings.Join(degraded, ", "))
+	}
+	return nil
+}
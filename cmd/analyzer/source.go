@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"ingress-migration-analyzer/pkg/analyze"
+	"ingress-migration-analyzer/pkg/common"
+	"ingress-migration-analyzer/pkg/discovery"
+	"ingress-migration-analyzer/pkg/render"
+	"ingress-migration-analyzer/pkg/rules"
+)
+
+var (
+	manifestFiles   []string
+	manifestDirs    []string
+	helmChart       string
+	helmValues      []string
+	helmReleaseName string
+	kustomizeDirs   []string
+
+	controllerClasses []string
+	annotationPrefix  string
+	ruleFiles         []string
+
+	allContexts  bool
+	kubeContexts []string
+
+	includeNamespaces []string
+	excludeNamespaces []string
+	namespaceSelector string
+	ingressSelector   string
+	ingressNames      []string
+
+	inspectCertificates bool
+)
+
+// addSourceFlags registers the input selection and discovery flags shared by scan and inventory
+func addSourceFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&manifestFiles, "from-file", nil, "Read Ingress manifests from YAML/JSON files instead of the cluster (repeatable, '-' for stdin)")
+	cmd.Flags().StringSliceVar(&manifestDirs, "dir", nil, "Read Ingress manifests from directories, recursively (repeatable)")
+	cmd.Flags().StringVar(&helmChart, "helm-chart", "", "Render a local Helm chart directory and analyze its Ingresses")
+	cmd.Flags().StringSliceVar(&helmValues, "values", nil, "Values file for --helm-chart (repeatable, later files take precedence)")
+	cmd.Flags().StringVar(&helmReleaseName, "release-name", render.DefaultReleaseName, "Release name used when rendering --helm-chart")
+	cmd.Flags().StringSliceVar(&kustomizeDirs, "kustomize", nil, "Build a Kustomize base or overlay directory and analyze its Ingresses (repeatable)")
+	cmd.Flags().StringVar(&annotationPrefix, "annotation-prefix", "", "Annotation prefix used by the controller (default: auto-detected from controller args, else "+rules.DefaultAnnotationPrefix+")")
+	cmd.Flags().StringSliceVar(&ruleFiles, "rules", nil, "Load annotation rules from a YAML/JSON file and merge them over the built-in rules (repeatable, later files take precedence)")
+	cmd.Flags().StringSliceVar(&controllerClasses, "controller-class", nil, "Additional IngressClass spec.controller value to treat as ingress-nginx (repeatable, default: "+discovery.NginxControllerName+")")
+	cmd.Flags().StringSliceVar(&includeNamespaces, "include-namespaces", nil, "Only scan namespaces matching these glob patterns (comma-separated, e.g. 'payments-*')")
+	cmd.Flags().StringSliceVar(&excludeNamespaces, "exclude-namespaces", nil, "Skip namespaces matching these glob patterns (comma-separated, e.g. 'kube-*,sandbox-*')")
+	cmd.Flags().StringVar(&namespaceSelector, "namespace-selector", "", "Only scan namespaces matching this label selector (e.g. 'team=payments')")
+	cmd.Flags().StringVarP(&ingressSelector, "selector", "l", "", "Only scan Ingresses matching this label selector (e.g. 'app.kubernetes.io/part-of=checkout')")
+	cmd.Flags().StringSliceVar(&ingressNames, "names", nil, "Only scan Ingresses whose name matches these glob patterns (comma-separated)")
+	cmd.Flags().BoolVar(&inspectCertificates, "inspect-certificates", false, "Read the TLS Secrets of every Ingress to check certificate expiry and host coverage (needs 'get secrets', which also exposes private keys)")
+	cmd.Flags().BoolVar(&allContexts, "all-contexts", false, "Analyze every context in the kubeconfig and write one fleet report")
+	cmd.Flags().StringSliceVar(&kubeContexts, "contexts", nil, "Analyze these kubeconfig contexts and write one fleet report (comma-separated)")
+}
+
+// scanOptions returns the scanner options selected by flags
+func scanOptions() discovery.ScanOptions {
+	return discovery.ScanOptions{
+		ControllerClasses: controllerClasses,
+		AnnotationPrefix:  strings.TrimSuffix(annotationPrefix, "/"),
+		IncludeNamespaces: includeNamespaces,
+		ExcludeNamespaces: excludeNamespaces,
+		NamespaceSelector: namespaceSelector,
+		Selector:          ingressSelector,
+		Names:             ingressNames,
+
+		InspectCertificates: inspectCertificates,
+	}
+}
+
+// isOffline reports whether the analysis reads local files instead of a cluster
+func isOffline() bool {
+	return len(manifestFiles) > 0 || len(manifestDirs) > 0 || helmChart != "" || len(kustomizeDirs) > 0
+}
+
+// clientOptions returns the authentication overrides selected by flags
+func clientOptions() discovery.ClientOptions {
+	return discovery.ClientOptions{
+		Impersonate:       impersonateUser,
+		ImpersonateGroups: impersonateGroups,
+		Token:             bearerToken,
+		Server:            apiServer,
+	}
+}
+
+// isFleet reports whether the analysis covers several kubeconfig contexts
+func isFleet() bool {
+	return allContexts || len(kubeContexts) > 0
+}
+
+// fleetContexts returns the kubeconfig contexts selected by --all-contexts or --contexts
+func fleetContexts() ([]string, error) {
+	if !allContexts {
+		return kubeContexts, nil
+	}
+
+	contexts, err := discovery.ListAvailableContexts(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kubeconfig contexts: %w", err)
+	}
+	if len(contexts) == 0 {
+		return nil, fmt.Errorf("no contexts found in kubeconfig %s", kubeconfig)
+	}
+	return contexts, nil
+}
+
+// manifestPaths returns all files and directories given with --from-file and --dir
+func manifestPaths() []string {
+	paths := append([]string{}, manifestFiles...)
+	return append(paths, manifestDirs...)
+}
+
+// printSource prints where the analysis input comes from
+func printSource() {
+	if helmChart != "" {
+		fmt.Printf("⎈ Helm chart: %s\n", helmChart)
+		if len(helmValues) > 0 {
+			fmt.Printf("📄 Values files: %s\n", strings.Join(helmValues, ", "))
+		}
+	}
+	if len(kustomizeDirs) > 0 {
+		fmt.Printf("🧩 Kustomize overlays: %s\n", strings.Join(kustomizeDirs, ", "))
+	}
+	if len(manifestPaths()) > 0 {
+		fmt.Printf("📂 Manifests: %s\n", strings.Join(manifestPaths(), ", "))
+	}
+	if len(includeNamespaces) > 0 {
+		fmt.Printf("✅ Include namespaces: %s\n", strings.Join(includeNamespaces, ", "))
+	}
+	if len(excludeNamespaces) > 0 {
+		fmt.Printf("🚫 Exclude namespaces: %s\n", strings.Join(excludeNamespaces, ", "))
+	}
+	if namespaceSelector != "" {
+		fmt.Printf("🏷️  Namespace selector: %s\n", namespaceSelector)
+	}
+	if ingressSelector != "" {
+		fmt.Printf("🏷️  Ingress selector: %s\n", ingressSelector)
+	}
+	if len(ingressNames) > 0 {
+		fmt.Printf("🔤 Ingress names: %s\n", strings.Join(ingressNames, ", "))
+	}
+	if len(ruleFiles) > 0 {
+		fmt.Printf("📐 Rule files: %s\n", strings.Join(ruleFiles, ", "))
+	}
+	if isOffline() {
+		return
+	}
+	if kubeconfig != "" {
+		fmt.Printf("🔧 Kubeconfig: %s\n", kubeconfig)
+	}
+	if contextName != "" {
+		fmt.Printf("🎯 Context: %s\n", contextName)
+	}
+	if apiServer != "" {
+		fmt.Printf("🖥️  Server: %s\n", apiServer)
+	}
+	if impersonateUser != "" || len(impersonateGroups) > 0 {
+		fmt.Printf("🎭 Impersonating: %s", impersonateUser)
+		if len(impersonateGroups) > 0 {
+			fmt.Printf(" (groups: %s)", strings.Join(impersonateGroups, ", "))
+		}
+		fmt.Println()
+	}
+	if allContexts {
+		fmt.Printf("🌐 Contexts: all in kubeconfig\n")
+	} else if len(kubeContexts) > 0 {
+		fmt.Printf("🌐 Contexts: %s\n", strings.Join(kubeContexts, ", "))
+	}
+}
+
+// newAnalyzer creates an analyzer for the selected input: local manifests
+// when offline flags are set, otherwise the Kubernetes cluster
+func newAnalyzer() (*analyze.Analyzer, error) {
+	if isOffline() {
+		loader, source := offlineLoader()
+		scanner := discovery.NewOfflineScanner(loader, source, namespace)
+		scanner.SetOptions(scanOptions())
+		return analyze.NewAnalyzerFromScanner(scanner), nil
+	}
+
+	fmt.Println("\n🔌 Testing Kubernetes connection...")
+	return newClusterAnalyzer(contextName)
+}
+
+// newClusterAnalyzer creates an analyzer for a kubeconfig context
+func newClusterAnalyzer(contextName string) (*analyze.Analyzer, error) {
+	client, err := common.CreateAnalyzerClient(kubeconfig, contextName, clientOptions())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScanPermissions(client); err != nil {
+		return nil, err
+	}
+
+	scanner := discovery.NewScanner(client, namespace)
+	scanner.SetOptions(scanOptions())
+	return analyze.NewAnalyzerFromScanner(scanner), nil
+}
+
+// offlineLoader combines all offline inputs into a single loader and
+// returns it with a description of the inputs for reports
+func offlineLoader() (discovery.ManifestLoader, string) {
+	var loaders []discovery.ManifestLoader
+	var sources []string
+
+	if paths := manifestPaths(); len(paths) > 0 {
+		loaders = append(loaders, discovery.ManifestFileLoader(paths))
+		sources = append(sources, fmt.Sprintf("manifests (%s)", strings.Join(paths, ", ")))
+	}
+
+	if helmChart != "" {
+		loaders = append(loaders, render.HelmLoader(render.HelmOptions{
+			ChartDir:    helmChart,
+			ValuesFiles: helmValues,
+			ReleaseName: helmReleaseName,
+			Namespace:   namespace,
+		}))
+		source := fmt.Sprintf("helm chart %s", helmChart)
+		if len(helmValues) > 0 {
+			source += fmt.Sprintf(" (values: %s)", strings.Join(helmValues, ", "))
+		}
+		sources = append(sources, source)
+	}
+
+	if len(kustomizeDirs) > 0 {
+		loaders = append(loaders, render.KustomizeLoader(kustomizeDirs))
+		sources = append(sources, fmt.Sprintf("kustomize overlays (%s)", strings.Join(kustomizeDirs, ", ")))
+	}
+
+	loader := func() ([]discovery.SourcedIngress, error) {
+		var all []discovery.SourcedIngress
+		for _, load := range loaders {
+			items, err := load()
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, items...)
+		}
+		return all, nil
+	}
+
+	return loader, strings.Join(sources, "; ")
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/analyze"
+)
+
+// runWatch watches the cluster and prints every change in migration risk
+// until interrupted. With --format json, changes are written to deltas as
+// JSON lines.
+func runWatch(deltas io.Writer) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	analyzer, err := newAnalyzer()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(deltas)
+	err = analyzer.Watch(ctx, func(delta models.RiskDelta) {
+		if format == "json" {
+			if err := encoder.Encode(delta); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Warning: failed to encode change: %v\n", err)
+			}
+			return
+		}
+		fmt.Printf("[%s] %s\n", delta.Time.Format("15:04:05"), analyze.FormatRiskDelta(delta))
+	})
+	if err != nil {
+		return fmt.Errorf("watch failed: %w", err)
+	}
+
+	fmt.Println("\n👋 Stopped watching")
+	return nil
+}
@@ -20,9 +20,109 @@ type IngressResource struct {
 	ClassName   string            `json:"className"`
 	Annotations map[string]string `json:"annotations"`
 	Labels      map[string]string `json:"labels"`
-	Hosts       []string          `json:"hosts"`
-	Paths       []string          `json:"paths"`
+	Hosts       []string          `json:"hosts"` // deduplicated across Rules
+	Paths       []string          `json:"paths"` // deduplicated across Rules
 	CreatedAt   time.Time         `json:"createdAt"`
+	Origin      *ResourceOrigin   `json:"origin,omitempty"`
+	Provenance  *Provenance       `json:"provenance,omitempty"`
+	// ResolvedClass is the IngressClass the Ingress resolved to, including the default class
+	ResolvedClass string `json:"resolvedClass,omitempty"`
+	// Controller is the spec.controller of ResolvedClass, when known
+	Controller string `json:"controller,omitempty"`
+	// ClassResolution explains why the Ingress was attributed to ingress-nginx
+	ClassResolution string `json:"classResolution,omitempty"`
+	// ServedBy lists the controllers (namespace/name) that serve this Ingress
+	ServedBy []string `json:"servedBy,omitempty"`
+	// AnnotationPrefix is the ingress-nginx annotation prefix the Ingress is analyzed with
+	AnnotationPrefix string `json:"annotationPrefix,omitempty"`
+	// Rules is the routing table: each host with its paths and their backends
+	Rules []IngressRule `json:"rules,omitempty"`
+	// DefaultBackend is spec.defaultBackend, serving requests no rule matches
+	DefaultBackend *BackendRef `json:"defaultBackend,omitempty"`
+	// Backends lists every backend the Ingress references, including the default backend
+	Backends []Backend `json:"backends,omitempty"`
+	// TLS lists the spec.tls entries and the certificates they reference
+	TLS []TLSConfig `json:"tls,omitempty"`
+	// Addresses are the IPs and hostnames from status.loadBalancer.ingress,
+	// where traffic for the Ingress enters the cluster today
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// TLSConfig is a spec.tls entry of an Ingress
+type TLSConfig struct {
+	Hosts       []string     `json:"hosts"`
+	SecretName  string       `json:"secretName,omitempty"`
+	Certificate *Certificate `json:"certificate,omitempty"` // parsed from the Secret's tls.crt
+	Issues      []string     `json:"issues,omitempty"`
+}
+
+// Certificate holds X.509 metadata of a TLS Secret's leaf certificate,
+// collected with --inspect-certificates. Reading the Secret transfers its
+// private key from the API server; the key is discarded without being
+// parsed or stored.
+type Certificate struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	DNSNames  []string  `json:"dnsNames,omitempty"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+// IngressRule is a rule of an Ingress: a host and the paths routed for it
+type IngressRule struct {
+	Host  string        `json:"host,omitempty"` // empty matches every host
+	Paths []IngressPath `json:"paths,omitempty"`
+}
+
+// IngressPath routes requests matching a path to a backend
+type IngressPath struct {
+	Path     string     `json:"path,omitempty"`
+	PathType string     `json:"pathType,omitempty"` // Exact, Prefix or ImplementationSpecific
+	Backend  BackendRef `json:"backend"`
+}
+
+// BackendRef is a backend as written on the Ingress
+type BackendRef struct {
+	Service  string `json:"service,omitempty"`
+	Port     string `json:"port,omitempty"`     // port number or name
+	Resource string `json:"resource,omitempty"` // Kind/name for resource backends
+}
+
+// Backend is an Ingress backend and what it resolved to in the cluster
+type Backend struct {
+	BackendRef
+	Default        bool     `json:"default,omitempty"` // spec.defaultBackend
+	Resolved       bool     `json:"resolved"`          // the Service was looked up in the cluster
+	ServiceType    string   `json:"serviceType,omitempty"`
+	ExternalName   string   `json:"externalName,omitempty"`
+	PortNumber     int32    `json:"portNumber,omitempty"` // Service port number the reference resolved to
+	TargetPort     string   `json:"targetPort,omitempty"`
+	ReadyEndpoints int      `json:"readyEndpoints"`
+	Issues         []string `json:"issues,omitempty"`
+}
+
+// ResourceOrigin records where an offline-analyzed resource was loaded from
+type ResourceOrigin struct {
+	File        string   `json:"file,omitempty"`        // manifest file path ("-" for stdin)
+	Chart       string   `json:"chart,omitempty"`       // Helm chart directory
+	Release     string   `json:"release,omitempty"`     // Helm release name used for rendering
+	ValuesFiles []string `json:"valuesFiles,omitempty"` // Helm values files, in precedence order
+	Template    string   `json:"template,omitempty"`    // chart template that rendered the resource
+	Overlay     string   `json:"overlay,omitempty"`     // Kustomize overlay directory that was built
+	// AnnotationSources maps annotation keys to the values file (and key path) that set them
+	AnnotationSources map[string]string `json:"annotationSources,omitempty"`
+}
+
+// Provenance records the owners, deployment tools and GitOps objects
+// that manage a resource, from its ownerReferences, labels and annotations
+type Provenance struct {
+	Owners            []string `json:"owners,omitempty"`            // ownerReferences as Kind/name
+	HelmRelease       string   `json:"helmRelease,omitempty"`       // namespace/name of the Helm release
+	ArgoCDApplication string   `json:"argoCDApplication,omitempty"` // Argo CD Application tracking the resource
+	FluxKustomization string   `json:"fluxKustomization,omitempty"` // namespace/name of the Flux Kustomization
+	FluxHelmRelease   string   `json:"fluxHelmRelease,omitempty"`   // namespace/name of the Flux HelmRelease
+	PartOf            string   `json:"partOf,omitempty"`            // app.kubernetes.io/part-of label
+	ManagedBy         string   `json:"managedBy,omitempty"`         // app.kubernetes.io/managed-by label
 }
 
 // ScanResult represents the results of cluster scanning
@@ -31,6 +131,94 @@ type ScanResult struct {
 	TotalIngresses int               `json:"totalIngresses"`
 	NginxIngresses []IngressResource `json:"nginxIngresses"`
 	ScanTime       time.Time         `json:"scanTime"`
+	Source         string            `json:"source,omitempty"` // set for offline scans, e.g. "manifests"
+	// ScanErrors lists the parts of the scan that failed; a scan without
+	// errors covers everything in scope
+	ScanErrors []ScanError `json:"scanErrors,omitempty"`
+	// Controllers holds the ingress-nginx controllers found in the cluster
+	Controllers []IngressController `json:"controllers,omitempty"`
+	// ControllerConfigs holds the ConfigMaps of those controllers
+	ControllerConfigs []ControllerConfig `json:"controllerConfigs,omitempty"`
+	// Filters records which subset of Ingresses was scanned, nil for all of them
+	Filters *ScanFilters `json:"filters,omitempty"`
+}
+
+// ScanFilters describes the namespace and Ingress filters of a scan
+type ScanFilters struct {
+	Namespace         string   `json:"namespace,omitempty"`
+	IncludeNamespaces []string `json:"includeNamespaces,omitempty"`
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+	NamespaceSelector string   `json:"namespaceSelector,omitempty"`
+	Selector          string   `json:"selector,omitempty"`
+	Names             []string `json:"names,omitempty"`
+}
+
+// IngressController describes an ingress-nginx controller Deployment or DaemonSet
+type IngressController struct {
+	Kind              string `json:"kind"` // Deployment or DaemonSet
+	Namespace         string `json:"namespace"`
+	Name              string `json:"name"`
+	Image             string `json:"image"`
+	Version           string `json:"version,omitempty"` // image tag
+	Replicas          int32  `json:"replicas"`
+	ReadyReplicas     int32  `json:"readyReplicas"`
+	IngressClass      string `json:"ingressClass"`    // --ingress-class
+	ControllerClass   string `json:"controllerClass"` // --controller-class
+	WatchNamespace    string `json:"watchNamespace,omitempty"`
+	AnnotationPrefix  string `json:"annotationPrefix"`
+	SSLPassthrough    bool   `json:"sslPassthrough"`
+	WatchWithoutClass bool   `json:"watchWithoutClass"` // --watch-ingress-without-class
+	Service           string `json:"service,omitempty"` // Service exposing the controller
+	ServiceType       string `json:"serviceType,omitempty"`
+	IngressCount      int    `json:"ingressCount"` // Ingresses attributed to this controller
+}
+
+// ControllerConfig is an ingress-nginx controller Deployment or DaemonSet and the
+// ConfigMap holding its global settings
+type ControllerConfig struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`                // controller workload name
+	ConfigMap string            `json:"configMap,omitempty"` // namespace/name from --configmap, empty when unset
+	Data      map[string]string `json:"data,omitempty"`
+	Error     string            `json:"error,omitempty"` // set when the ConfigMap could not be read
+	// StreamServices are raw TCP/UDP ports from --tcp-services-configmap and --udp-services-configmap
+	StreamServices []StreamService `json:"streamServices,omitempty"`
+}
+
+// StreamService is a TCP or UDP port the controller exposes directly,
+// configured through the tcp-services or udp-services ConfigMap
+type StreamService struct {
+	Protocol      string `json:"protocol"`  // TCP or UDP
+	Port          string `json:"port"`      // port exposed by the controller
+	ConfigMap     string `json:"configMap"` // namespace/name of the services ConfigMap
+	Namespace     string `json:"namespace"`
+	Service       string `json:"service"`
+	ServicePort   string `json:"servicePort"`
+	TargetPort    string `json:"targetPort,omitempty"`    // resolved from the Service
+	ProxyProtocol string `json:"proxyProtocol,omitempty"` // "decode", "encode" or "decode,encode"
+	Error         string `json:"error,omitempty"`         // set when the entry or Service could not be resolved
+}
+
+// ScanStage identifies the part of a scan a ScanError comes from
+type ScanStage string
+
+const (
+	StageIngresses          ScanStage = "ingresses"          // Ingresses of a namespace are missing
+	StageIngressClasses     ScanStage = "ingressClasses"     // classes were matched by name only
+	StageControllers        ScanStage = "controllers"        // controller analysis was skipped
+	StageControllerServices ScanStage = "controllerServices" // controller Service types are unknown
+	StageTLSSecrets         ScanStage = "tlsSecrets"         // certificates of a namespace were not checked
+	StageBackends           ScanStage = "backends"           // backend Services of a namespace were not resolved
+	StageStreamServices     ScanStage = "streamServices"     // TCP/UDP stream targets of a namespace were not resolved
+)
+
+// ScanError records an API failure that left part of a scan incomplete,
+// after transient errors were retried
+type ScanError struct {
+	Stage     ScanStage `json:"stage"`
+	Namespace string    `json:"namespace,omitempty"`
+	Error     string    `json:"error"`
+	Impact    string    `json:"impact"`
 }
 
 // AnnotationRule defines how to classify a specific annotation
@@ -39,8 +227,42 @@ type AnnotationRule struct {
 	Pattern       string    `json:"pattern"` // annotation key pattern
 	RiskLevel     RiskLevel `json:"riskLevel"`
 	Description   string    `json:"description"`
-	MigrationNote string    `json:"migrationNote"` // What to do about it
-	SourceURL     string    `json:"sourceUrl"`     // Documentation source
+	MigrationNote string    `json:"migrationNote"`    // What to do about it
+	SourceURL     string    `json:"sourceUrl"`        // Documentation source
+	Source        string    `json:"source,omitempty"` // rules file that defined or overrode the rule
+	// Key is the annotation key a matched rule was found on, as written on
+	// the Ingress; a regexp pattern can match several keys
+	Key string `json:"key,omitempty"`
+	// Conditions refine the rule by annotation value; the first matching
+	// condition applies
+	Conditions []ValueCondition `json:"conditions,omitempty"`
+}
+
+// ValueCondition overrides the risk level and migration note of an
+// AnnotationRule when the annotation value matches
+type ValueCondition struct {
+	Value         string    `json:"value"` // regexp matching the whole value, ignoring case
+	RiskLevel     RiskLevel `json:"riskLevel,omitempty"`
+	MigrationNote string    `json:"migrationNote,omitempty"`
+}
+
+// CompoundRule classifies a combination of annotations whose migration risk
+// differs from the risk of each annotation on its own
+type CompoundRule struct {
+	Name string `json:"name"`
+	// Annotations must all be set for the rule to match
+	Annotations []AnnotationCondition `json:"annotations"`
+	// RiskLevel replaces the risk level of the matched annotations, raising or
+	// lowering it; empty keeps their risk and only adds the warning
+	RiskLevel RiskLevel `json:"riskLevel,omitempty"`
+	Warning   string    `json:"warning"` // guidance for the combination
+	SourceURL string    `json:"sourceUrl"`
+}
+
+// AnnotationCondition matches an annotation of a CompoundRule
+type AnnotationCondition struct {
+	Pattern string `json:"pattern"`         // annotation key pattern
+	Value   string `json:"value,omitempty"` // regexp matching the whole value, ignoring case; empty matches any value
 }
 
 // IngressAnalysis represents the analysis result for a single Ingress
@@ -49,7 +271,36 @@ type IngressAnalysis struct {
 	MatchedRules       []AnnotationRule `json:"matchedRules"`
 	RiskLevel          RiskLevel        `json:"riskLevel"`
 	UnknownAnnotations []string         `json:"unknownAnnotations"`
-	Warnings           []string         `json:"warnings"`
+	// CompoundRules are the annotation combinations found on the Ingress
+	CompoundRules []CompoundRule `json:"compoundRules,omitempty"`
+	Warnings      []string       `json:"warnings"`
+	// Application is the application owning the Ingress, nil when nothing identifies one
+	Application *Application `json:"application,omitempty"`
+}
+
+// Application identifies the application an Ingress belongs to, so
+// migration work can go to the team that owns it
+type Application struct {
+	Name   string `json:"name"`
+	Source string `json:"source"` // what identified it, e.g. "Helm release"
+}
+
+// GlobalSettingsAnalysis represents the analysis of a controller ConfigMap
+type GlobalSettingsAnalysis struct {
+	Controller   ControllerConfig `json:"controller"`
+	MatchedRules []AnnotationRule `json:"matchedRules"`
+	RiskLevel    RiskLevel        `json:"riskLevel"`
+	UnknownKeys  []string         `json:"unknownKeys"`
+}
+
+// StreamServiceAnalysis represents a TCP/UDP exposure that needs a
+// TCPRoute or UDPRoute and a Gateway listener
+type StreamServiceAnalysis struct {
+	Controller    string        `json:"controller"` // namespace/name of the controller
+	Service       StreamService `json:"service"`
+	RouteKind     string        `json:"routeKind"` // TCPRoute or UDPRoute
+	RiskLevel     RiskLevel     `json:"riskLevel"`
+	MigrationNote string        `json:"migrationNote"`
 }
 
 // NamespaceSummary provides aggregated stats for a namespace
@@ -66,6 +317,38 @@ type AnalysisSummary struct {
 	ManualCount    int                         `json:"manualCount"`
 	HighRiskCount  int                         `json:"highRiskCount"`
 	ByNamespace    map[string]NamespaceSummary `json:"byNamespace"`
+	// ScanErrors counts scanResult.scanErrors; zero means the scan was complete
+	ScanErrors int `json:"scanErrors"`
+}
+
+// AnnotationUsage tracks how an annotation is used across the cluster
+type AnnotationUsage struct {
+	Key           string         `json:"key"`
+	UniqueValues  []string       `json:"uniqueValues"`
+	UsageCount    int            `json:"usageCount"`
+	Namespaces    []string       `json:"namespaces"`
+	ValueExamples map[string]int `json:"valueExamples"` // value -> count
+	Risk          RiskLevel      `json:"risk"`
+	Description   string         `json:"description"`
+	MigrationNote string         `json:"migrationNote"`
+	SourceURL     string         `json:"sourceUrl"`
+}
+
+// AnnotationInventory provides comprehensive annotation analysis
+type AnnotationInventory struct {
+	AllAnnotations     map[string]*AnnotationUsage `json:"allAnnotations"`
+	NginxAnnotations   map[string]*AnnotationUsage `json:"nginxAnnotations"`
+	UnknownAnnotations map[string]*AnnotationUsage `json:"unknownAnnotations"`
+	Summary            InventorySummary            `json:"summary"`
+}
+
+// InventorySummary provides high-level inventory statistics
+type InventorySummary struct {
+	TotalUniqueAnnotations  int    `json:"totalUniqueAnnotations"`
+	NginxAnnotationsCount   int    `json:"nginxAnnotationsCount"`
+	UnknownAnnotationsCount int    `json:"unknownAnnotationsCount"`
+	MostUsedAnnotation      string `json:"mostUsedAnnotation"`
+	MostComplexNamespace    string `json:"mostComplexNamespace"`
 }
 
 // ClusterAnalysis represents the complete analysis result
@@ -73,5 +356,67 @@ type ClusterAnalysis struct {
 	ScanResult ScanResult        `json:"scanResult"`
 	Analyses   []IngressAnalysis `json:"analyses"`
 	Summary    AnalysisSummary   `json:"summary"`
-	Inventory  interface{}       `json:"inventory,omitempty"`
+	// Inventory is the annotation inventory, set by the inventory command
+	Inventory *AnnotationInventory `json:"inventory,omitempty"`
+	// Controllers inventories the ingress-nginx controllers and the Ingresses each one serves
+	Controllers []IngressController `json:"controllers,omitempty"`
+	// GlobalSettings analyzes each controller's ConfigMap ("Global controller settings")
+	GlobalSettings []GlobalSettingsAnalysis `json:"globalSettings,omitempty"`
+	// StreamServices lists TCP/UDP ports exposed through the controllers
+	StreamServices []StreamServiceAnalysis `json:"streamServices,omitempty"`
+	// DNSCutover groups hosts by the load balancer address they point to
+	DNSCutover []AddressGroup `json:"dnsCutover,omitempty"`
+}
+
+// AddressGroup lists the hosts served at one load balancer address. Their
+// DNS records move together when traffic shifts to a Gateway's address.
+type AddressGroup struct {
+	Address   string   `json:"address,omitempty"` // empty for Ingresses without a status address
+	Hosts     []string `json:"hosts,omitempty"`
+	Ingresses []string `json:"ingresses"` // namespace/name
+}
+
+// FleetAnalysis aggregates the analyses of several clusters
+type FleetAnalysis struct {
+	ScanTime time.Time       `json:"scanTime"`
+	Clusters []ClusterResult `json:"clusters"`
+	Summary  FleetSummary    `json:"summary"`
+}
+
+// ClusterResult is the analysis of one kubeconfig context in a fleet scan
+type ClusterResult struct {
+	Context  string           `json:"context"`
+	Analysis *ClusterAnalysis `json:"analysis,omitempty"`
+	Error    string           `json:"error,omitempty"` // set when the cluster could not be analyzed
+}
+
+// FleetSummary provides fleet-wide risk and annotation usage statistics
+type FleetSummary struct {
+	Clusters       int                    `json:"clusters"`
+	FailedClusters int                    `json:"failedClusters"`
+	TotalIngresses int                    `json:"totalIngresses"`
+	AutoCount      int                    `json:"autoCount"`
+	ManualCount    int                    `json:"manualCount"`
+	HighRiskCount  int                    `json:"highRiskCount"`
+	Annotations    []FleetAnnotationUsage `json:"annotations"` // sorted by usage, most used first
+}
+
+// FleetAnnotationUsage counts how an ingress-nginx annotation is used across the fleet
+type FleetAnnotationUsage struct {
+	Key        string    `json:"key"`
+	Risk       RiskLevel `json:"risk"`
+	UsageCount int       `json:"usageCount"` // Ingresses using the annotation
+	Clusters   []string  `json:"clusters"`   // contexts where it is used
+}
+
+// RiskDelta describes how the analysis of an Ingress or controller ConfigMap
+// changed while watching the cluster
+type RiskDelta struct {
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`               // Ingress or ConfigMap
+	Key      string    `json:"key"`                // namespace/name
+	Previous RiskLevel `json:"previous,omitempty"` // empty when the object is new
+	Current  RiskLevel `json:"current,omitempty"`  // empty when the object was removed
+	Added    []string  `json:"added,omitempty"`    // annotations or settings that started matching a rule
+	Removed  []string  `json:"removed,omitempty"`  // annotations or settings that stopped matching a rule
 }
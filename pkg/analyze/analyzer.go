@@ -3,6 +3,7 @@ package analyze
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"ingress-migration-analyzer/internal/models"
@@ -23,6 +24,14 @@ func NewAnalyzer(client *discovery.Client, namespace string) *Analyzer {
 	}
 }
 
+// NewAnalyzerFromScanner creates an analyzer around an existing scanner,
+// such as one created with discovery.NewOfflineScanner
+func NewAnalyzerFromScanner(scanner *discovery.Scanner) *Analyzer {
+	return &Analyzer{
+		scanner: scanner,
+	}
+}
+
 // AnalyzeCluster performs complete cluster analysis
 func (a *Analyzer) AnalyzeCluster(ctx context.Context) (*models.ClusterAnalysis, error) {
 	fmt.Println("🔍 Starting cluster analysis...")
@@ -30,7 +39,7 @@ func (a *Analyzer) AnalyzeCluster(ctx context.Context) (*models.ClusterAnalysis,
 	// Scan cluster for ingress resources
 	scanResult, err := a.scanner.ScanCluster(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("cluster scan failed: %w", err)
+		return nil, fmt.Errorf("scan failed: %w", err)
 	}
 
 	fmt.Printf("📊 Analyzing %d ingress-nginx resources...\n", len(scanResult.NginxIngresses))
@@ -44,14 +53,27 @@ func (a *Analyzer) AnalyzeCluster(ctx context.Context) (*models.ClusterAnalysis,
 
 	// Generate summary statistics
 	summary := a.generateSummary(analyses)
+	summary.ScanErrors = len(scanResult.ScanErrors)
 
 	clusterAnalysis := &models.ClusterAnalysis{
-		ScanResult: *scanResult,
-		Analyses:   analyses,
-		Summary:    summary,
+		ScanResult:     *scanResult,
+		Analyses:       analyses,
+		Summary:        summary,
+		Controllers:    a.countControllerIngresses(scanResult.Controllers, analyses),
+		GlobalSettings: a.analyzeControllerConfigs(scanResult.ControllerConfigs),
+		StreamServices: a.analyzeStreamServices(scanResult.ControllerConfigs),
+		DNSCutover:     a.groupByAddress(analyses),
 	}
 
 	a.printAnalysisSummary(summary)
+	if len(clusterAnalysis.StreamServices) > 0 {
+		fmt.Printf("\n🔌 Found %d TCP/UDP ports exposed through tcp-services/udp-services ConfigMaps (need TCPRoute/UDPRoute)\n",
+			len(clusterAnalysis.StreamServices))
+	}
+	if summary.ScanErrors > 0 {
+		fmt.Printf("\n⚠️  Warning: the scan is incomplete, %d API errors left parts of the cluster unscanned (listed in the report)\n",
+			summary.ScanErrors)
+	}
 
 	return clusterAnalysis, nil
 }
@@ -59,28 +81,105 @@ func (a *Analyzer) AnalyzeCluster(ctx context.Context) (*models.ClusterAnalysis,
 // analyzeIngress analyzes a single Ingress resource
 func (a *Analyzer) analyzeIngress(resource models.IngressResource) models.IngressAnalysis {
 	// Match annotations against rules
-	matchedRules := rules.MatchAnnotations(resource.Annotations)
+	matchedRules := rules.MatchAnnotationsWithPrefix(resource.Annotations, resource.AnnotationPrefix)
+
+	// Combinations of annotations may raise or lower their risk
+	compoundRules := rules.MatchCompoundRulesWithPrefix(resource.Annotations, resource.AnnotationPrefix)
+	matchedRules = rules.ApplyCompoundRules(matchedRules, compoundRules)
 	
 	// Determine overall risk level
 	riskLevel := rules.GetHighestRiskLevel(matchedRules)
 	
 	// Find unknown nginx annotations
-	unknownAnnotations := rules.GetUnknownNginxAnnotations(resource.Annotations)
+	unknownAnnotations := rules.GetUnknownNginxAnnotationsWithPrefix(resource.Annotations, resource.AnnotationPrefix)
 	
 	// Generate warnings
-	warnings := a.generateWarnings(resource, matchedRules)
+	warnings := a.generateWarnings(resource, matchedRules, compoundRules)
 
 	return models.IngressAnalysis{
 		Resource:           resource,
 		MatchedRules:       matchedRules,
 		RiskLevel:          riskLevel,
 		UnknownAnnotations: unknownAnnotations,
+		CompoundRules:      compoundRules,
 		Warnings:           warnings,
+		Application:        owningApplication(resource),
+	}
+}
+
+// countControllerIngresses returns the controllers with the number of
+// analyzed Ingresses each one serves
+func (a *Analyzer) countControllerIngresses(controllers []models.IngressController, analyses []models.IngressAnalysis) []models.IngressController {
+	counted := make([]models.IngressController, len(controllers))
+	for i, controller := range controllers {
+		key := controller.Namespace + "/" + controller.Name
+		controller.IngressCount = 0
+		for _, analysis := range analyses {
+			for _, servedBy := range analysis.Resource.ServedBy {
+				if servedBy == key {
+					controller.IngressCount++
+				}
+			}
+		}
+		counted[i] = controller
+	}
+	return counted
+}
+
+// analyzeControllerConfigs runs each controller ConfigMap through the ConfigMap rules
+func (a *Analyzer) analyzeControllerConfigs(configs []models.ControllerConfig) []models.GlobalSettingsAnalysis {
+	var analyses []models.GlobalSettingsAnalysis
+	for _, config := range configs {
+		matchedRules := rules.MatchConfigMapSettings(config.Data)
+		analyses = append(analyses, models.GlobalSettingsAnalysis{
+			Controller:   config,
+			MatchedRules: matchedRules,
+			RiskLevel:    rules.GetHighestRiskLevel(matchedRules),
+			UnknownKeys:  rules.GetUnknownConfigMapKeys(config.Data),
+		})
+	}
+	return analyses
+}
+
+// analyzeStreamServices turns TCP/UDP ports exposed by the controllers into
+// migration items that need an L4 route and a Gateway listener
+func (a *Analyzer) analyzeStreamServices(configs []models.ControllerConfig) []models.StreamServiceAnalysis {
+	var analyses []models.StreamServiceAnalysis
+	for _, config := range configs {
+		for _, service := range config.StreamServices {
+			routeKind := "TCPRoute"
+			if service.Protocol == "UDP" {
+				routeKind = "UDPRoute"
+			}
+
+			analysis := models.StreamServiceAnalysis{
+				Controller: config.Namespace + "/" + config.Name,
+				Service:    service,
+				RouteKind:  routeKind,
+				RiskLevel:  models.RiskManual,
+				MigrationNote: fmt.Sprintf("Add a %s listener on port %s to the Gateway and a %s to %s/%s:%s. "+
+					"%s is in the Gateway API experimental channel; verify your implementation supports it.",
+					service.Protocol, service.Port, routeKind, service.Namespace, service.Service, service.ServicePort, routeKind),
+			}
+
+			switch {
+			case service.Error != "":
+				analysis.MigrationNote = "Entry could not be resolved (" + service.Error + "). " +
+					"Confirm whether this port is still needed before migrating; it is exposed by the controller today."
+			case service.ProxyProtocol != "":
+				analysis.RiskLevel = models.RiskHigh
+				analysis.MigrationNote += " PROXY protocol (" + service.ProxyProtocol + ") is implementation-specific " +
+					"and must be configured on both the Gateway and the load balancer."
+			}
+
+			analyses = append(analyses, analysis)
+		}
 	}
+	return analyses
 }
 
 // generateWarnings creates warnings for potential issues
-func (a *Analyzer) generateWarnings(resource models.IngressResource, matchedRules []models.AnnotationRule) []string {
+func (a *Analyzer) generateWarnings(resource models.IngressResource, matchedRules []models.AnnotationRule, compoundRules []models.CompoundRule) []string {
 	var warnings []string
 
 	// Warn about snippets
@@ -90,12 +189,40 @@ func (a *Analyzer) generateWarnings(resource models.IngressResource, matchedRule
 		}
 	}
 
+	// Warn about annotations that interact
+	for _, compound := range compoundRules {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", compound.Name, compound.Warning))
+	}
+
 	// Warn about unknown annotations
-	unknown := rules.GetUnknownNginxAnnotations(resource.Annotations)
+	unknown := rules.GetUnknownNginxAnnotationsWithPrefix(resource.Annotations, resource.AnnotationPrefix)
 	if len(unknown) > 0 {
 		warnings = append(warnings, fmt.Sprintf("Contains %d unknown nginx annotations", len(unknown)))
 	}
 
+	// Warn about broken or hard-to-migrate backends
+	var brokenBackends int
+	for _, backend := range resource.Backends {
+		if len(backend.Issues) > 0 {
+			brokenBackends++
+		}
+	}
+	if brokenBackends > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d of %d backends have issues to fix before moving traffic", brokenBackends, len(resource.Backends)))
+	}
+
+	// Warn about TLS problems and cert-manager integration
+	var tlsIssues int
+	for _, tls := range resource.TLS {
+		tlsIssues += len(tls.Issues)
+	}
+	if tlsIssues > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d TLS issues to fix before moving hosts to Gateway listeners", tlsIssues))
+	}
+	if len(CertManagerAnnotations(resource.Annotations)) > 0 {
+		warnings = append(warnings, "Managed by cert-manager: enable its Gateway API support and move the cert-manager.io annotations to the Gateway")
+	}
+
 	// Warn about deprecated class annotation
 	if class, exists := resource.Annotations["kubernetes.io/ingress.class"]; exists && class == "nginx" {
 		if resource.ClassName == "" {
@@ -106,6 +233,18 @@ func (a *Analyzer) generateWarnings(resource models.IngressResource, matchedRule
 	return warnings
 }
 
+// CertManagerAnnotations returns the cert-manager.io annotation keys of a resource, sorted
+func CertManagerAnnotations(annotations map[string]string) []string {
+	var keys []string
+	for key := range annotations {
+		if strings.HasPrefix(key, "cert-manager.io/") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // generateSummary creates aggregate statistics
 func (a *Analyzer) generateSummary(analyses []models.IngressAnalysis) models.AnalysisSummary {
 	summary := models.AnalysisSummary{
@@ -0,0 +1,36 @@
+package analyze
+
+import (
+	"ingress-migration-analyzer/internal/models"
+)
+
+// owningApplication identifies the application an Ingress belongs to from
+// its provenance, preferring the most specific deployment unit: an Argo CD
+// Application, a Flux or Helm release, the app.kubernetes.io/part-of
+// label, a Flux Kustomization and finally the owning object. It returns
+// nil when nothing identifies one.
+func owningApplication(resource models.IngressResource) *models.Application {
+	provenance := resource.Provenance
+	if provenance == nil {
+		provenance = &models.Provenance{}
+	}
+
+	switch {
+	case provenance.ArgoCDApplication != "":
+		return &models.Application{Name: provenance.ArgoCDApplication, Source: "Argo CD Application"}
+	case provenance.FluxHelmRelease != "":
+		return &models.Application{Name: provenance.FluxHelmRelease, Source: "Flux HelmRelease"}
+	case provenance.HelmRelease != "":
+		return &models.Application{Name: provenance.HelmRelease, Source: "Helm release"}
+	case resource.Origin != nil && resource.Origin.Release != "":
+		// Rendered offline with --helm-chart, so Helm has not annotated it yet
+		return &models.Application{Name: resource.Namespace + "/" + resource.Origin.Release, Source: "Helm release"}
+	case provenance.PartOf != "":
+		return &models.Application{Name: provenance.PartOf, Source: "part-of label"}
+	case provenance.FluxKustomization != "":
+		return &models.Application{Name: provenance.FluxKustomization, Source: "Flux Kustomization"}
+	case len(provenance.Owners) > 0:
+		return &models.Application{Name: resource.Namespace + "/" + provenance.Owners[0], Source: "owner"}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package analyze
+
+import (
+	"testing"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+func TestOwningApplication(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource models.IngressResource
+		want     *models.Application
+	}{
+		{
+			name:     "unattributed",
+			resource: models.IngressResource{Namespace: "shop"},
+		},
+		{
+			name: "Argo CD wins over Helm",
+			resource: models.IngressResource{Namespace: "shop", Provenance: &models.Provenance{
+				ArgoCDApplication: "shop-web", HelmRelease: "shop/web", PartOf: "storefront",
+			}},
+			want: &models.Application{Name: "shop-web", Source: "Argo CD Application"},
+		},
+		{
+			name: "Helm release wins over part-of and Flux Kustomization",
+			resource: models.IngressResource{Namespace: "shop", Provenance: &models.Provenance{
+				HelmRelease: "shop/web", PartOf: "storefront", FluxKustomization: "flux-system/apps",
+			}},
+			want: &models.Application{Name: "shop/web", Source: "Helm release"},
+		},
+		{
+			name: "chart rendered offline",
+			resource: models.IngressResource{Namespace: "shop", Origin: &models.ResourceOrigin{
+				Chart: "./charts/web", Release: "web",
+			}},
+			want: &models.Application{Name: "shop/web", Source: "Helm release"},
+		},
+		{
+			name: "owner reference",
+			resource: models.IngressResource{Namespace: "search", Provenance: &models.Provenance{
+				Owners: []string{"Elasticsearch/logs"}, ManagedBy: "eck-operator",
+			}},
+			want: &models.Application{Name: "search/Elasticsearch/logs", Source: "owner"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := owningApplication(tt.resource)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("owningApplication() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package analyze
+
+import (
+	"slices"
+	"sort"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// groupByAddress groups the hosts of the analyzed Ingresses by the load
+// balancer address in their status, for planning the DNS cutover to a
+// Gateway. Ingresses without an address form a group with an empty
+// address, listed last. It returns nil when no Ingress has an address,
+// as for offline scans of manifests.
+func (a *Analyzer) groupByAddress(analyses []models.IngressAnalysis) []models.AddressGroup {
+	groups := make(map[string]*models.AddressGroup)
+	var withAddress bool
+	for _, analysis := range analyses {
+		resource := analysis.Resource
+		addresses := resource.Addresses
+		if len(addresses) == 0 {
+			addresses = []string{""}
+		} else {
+			withAddress = true
+		}
+
+		for _, address := range addresses {
+			group, exists := groups[address]
+			if !exists {
+				group = &models.AddressGroup{Address: address}
+				groups[address] = group
+			}
+			for _, host := range resource.Hosts {
+				if !slices.Contains(group.Hosts, host) {
+					group.Hosts = append(group.Hosts, host)
+				}
+			}
+			group.Ingresses = append(group.Ingresses, resource.Namespace+"/"+resource.Name)
+		}
+	}
+	if !withAddress {
+		return nil
+	}
+
+	var result []models.AddressGroup
+	for _, group := range groups {
+		sort.Strings(group.Hosts)
+		sort.Strings(group.Ingresses)
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].Address == "") != (result[j].Address == "") {
+			return result[j].Address == ""
+		}
+		return result[i].Address < result[j].Address
+	})
+	return result
+}
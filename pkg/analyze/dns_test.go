@@ -0,0 +1,35 @@
+package analyze
+
+import (
+	"reflect"
+	"testing"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+func TestGroupByAddress(t *testing.T) {
+	analysis := func(namespace, name string, hosts, addresses []string) models.IngressAnalysis {
+		return models.IngressAnalysis{Resource: models.IngressResource{
+			Namespace: namespace, Name: name, Hosts: hosts, Addresses: addresses,
+		}}
+	}
+	analyses := []models.IngressAnalysis{
+		analysis("shop", "web", []string{"shop.example.com", "www.example.com"}, []string{"203.0.113.10"}),
+		analysis("shop", "api", []string{"api.example.com", "shop.example.com"}, []string{"203.0.113.10"}),
+		analysis("internal", "admin", []string{"admin.corp.example.com"}, []string{"lb-internal.elb.amazonaws.com"}),
+		analysis("staging", "web", []string{"staging.example.com"}, nil),
+	}
+
+	want := []models.AddressGroup{
+		{Address: "203.0.113.10", Hosts: []string{"api.example.com", "shop.example.com", "www.example.com"}, Ingresses: []string{"shop/api", "shop/web"}},
+		{Address: "lb-internal.elb.amazonaws.com", Hosts: []string{"admin.corp.example.com"}, Ingresses: []string{"internal/admin"}},
+		{Hosts: []string{"staging.example.com"}, Ingresses: []string{"staging/web"}},
+	}
+	if got := (&Analyzer{}).groupByAddress(analyses); !reflect.DeepEqual(got, want) {
+		t.Errorf("groupByAddress() = %+v, want %+v", got, want)
+	}
+
+	if got := (&Analyzer{}).groupByAddress(analyses[3:]); got != nil {
+		t.Errorf("groupByAddress() without addresses = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,130 @@
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/rules"
+)
+
+// DefaultFleetConcurrency is the number of clusters analyzed at the same time
+const DefaultFleetConcurrency = 5
+
+// AnalyzerFactory creates the analyzer for a kubeconfig context
+type AnalyzerFactory func(contextName string) (*Analyzer, error)
+
+// AnalyzeFleet analyzes every context concurrently, at most concurrency at
+// a time. A cluster that cannot be reached or analyzed is recorded in the
+// result instead of failing the whole fleet.
+func AnalyzeFleet(ctx context.Context, contexts []string, newAnalyzer AnalyzerFactory, concurrency int) *models.FleetAnalysis {
+	if concurrency < 1 {
+		concurrency = DefaultFleetConcurrency
+	}
+
+	results := make([]models.ClusterResult, len(contexts))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, contextName := range contexts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, contextName string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = analyzeFleetCluster(ctx, contextName, newAnalyzer)
+			if results[i].Error != "" {
+				fmt.Printf("❌ %s: %s\n", contextName, results[i].Error)
+			} else {
+				fmt.Printf("✅ %s: analyzed %d ingress-nginx resources\n", contextName, results[i].Analysis.Summary.TotalIngresses)
+			}
+		}(i, contextName)
+	}
+	wg.Wait()
+
+	return &models.FleetAnalysis{
+		ScanTime: time.Now(),
+		Clusters: results,
+		Summary:  BuildFleetSummary(results),
+	}
+}
+
+// analyzeFleetCluster analyzes a single cluster of a fleet
+func analyzeFleetCluster(ctx context.Context, contextName string, newAnalyzer AnalyzerFactory) models.ClusterResult {
+	result := models.ClusterResult{Context: contextName}
+
+	analyzer, err := newAnalyzer(contextName)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	analysis, err := analyzer.AnalyzeCluster(ctx)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Analysis = analysis
+	return result
+}
+
+// BuildFleetSummary aggregates risk counts and ingress-nginx annotation
+// usage across all successfully analyzed clusters
+func BuildFleetSummary(clusters []models.ClusterResult) models.FleetSummary {
+	summary := models.FleetSummary{Clusters: len(clusters)}
+	usage := make(map[string]*models.FleetAnnotationUsage)
+
+	for _, cluster := range clusters {
+		if cluster.Analysis == nil {
+			summary.FailedClusters++
+			continue
+		}
+
+		clusterSummary := cluster.Analysis.Summary
+		summary.TotalIngresses += clusterSummary.TotalIngresses
+		summary.AutoCount += clusterSummary.AutoCount
+		summary.ManualCount += clusterSummary.ManualCount
+		summary.HighRiskCount += clusterSummary.HighRiskCount
+
+		for _, analysis := range cluster.Analysis.Analyses {
+			for key, value := range analysis.Resource.Annotations {
+				canonicalKey, ok := rules.CanonicalAnnotationKey(key, analysis.Resource.AnnotationPrefix)
+				if !ok {
+					continue
+				}
+
+				annotation, exists := usage[canonicalKey]
+				if !exists {
+					annotation = &models.FleetAnnotationUsage{Key: canonicalKey, Risk: models.RiskLevel("UNKNOWN")}
+					usage[canonicalKey] = annotation
+				}
+				// The risk of the riskiest value seen across the fleet
+				if rule := rules.GetRuleForAnnotationValue(canonicalKey, value); rule != nil &&
+					(annotation.Risk == "UNKNOWN" || rules.HigherRisk(rule.RiskLevel, annotation.Risk)) {
+					annotation.Risk = rule.RiskLevel
+				}
+				annotation.UsageCount++
+				if n := len(annotation.Clusters); n == 0 || annotation.Clusters[n-1] != cluster.Context {
+					annotation.Clusters = append(annotation.Clusters, cluster.Context)
+				}
+			}
+		}
+	}
+
+	for _, annotation := range usage {
+		summary.Annotations = append(summary.Annotations, *annotation)
+	}
+	sort.Slice(summary.Annotations, func(i, j int) bool {
+		if summary.Annotations[i].UsageCount != summary.Annotations[j].UsageCount {
+			return summary.Annotations[i].UsageCount > summary.Annotations[j].UsageCount
+		}
+		return summary.Annotations[i].Key < summary.Annotations[j].Key
+	})
+
+	return summary
+}
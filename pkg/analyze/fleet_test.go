@@ -0,0 +1,107 @@
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/discovery"
+)
+
+func TestAnalyzeFleet(t *testing.T) {
+	nginx := "nginx"
+	ingress := func(name string, annotations map[string]string) discovery.SourcedIngress {
+		return discovery.SourcedIngress{Ingress: networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+			Spec:       networkingv1.IngressSpec{IngressClassName: &nginx},
+		}}
+	}
+
+	clusters := map[string][]discovery.SourcedIngress{
+		"prod": {
+			ingress("web", map[string]string{"nginx.ingress.kubernetes.io/ssl-redirect": "true"}),
+			ingress("api", map[string]string{
+				"nginx.ingress.kubernetes.io/ssl-redirect":          "true",
+				"nginx.ingress.kubernetes.io/configuration-snippet": "more_set_headers \"X: y\";",
+			}),
+		},
+		"staging": {
+			ingress("web", map[string]string{"nginx.ingress.kubernetes.io/ssl-redirect": "true"}),
+		},
+	}
+
+	newAnalyzer := func(contextName string) (*Analyzer, error) {
+		ingresses, exists := clusters[contextName]
+		if !exists {
+			return nil, fmt.Errorf("context %q not found", contextName)
+		}
+		loader := func() ([]discovery.SourcedIngress, error) { return ingresses, nil }
+		return NewAnalyzerFromScanner(discovery.NewOfflineScanner(loader, contextName, "")), nil
+	}
+
+	fleet := AnalyzeFleet(context.Background(), []string{"prod", "missing", "staging"}, newAnalyzer, 2)
+
+	var order []string
+	for _, cluster := range fleet.Clusters {
+		order = append(order, cluster.Context)
+	}
+	if want := []string{"prod", "missing", "staging"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("cluster order = %v, want %v", order, want)
+	}
+	if fleet.Clusters[1].Error == "" || fleet.Clusters[1].Analysis != nil {
+		t.Errorf("missing context: got %+v, want an error and no analysis", fleet.Clusters[1])
+	}
+
+	summary := fleet.Summary
+	if summary.Clusters != 3 || summary.FailedClusters != 1 || summary.TotalIngresses != 3 {
+		t.Errorf("summary = %+v, want 3 clusters, 1 failed, 3 ingresses", summary)
+	}
+	if summary.HighRiskCount != 1 {
+		t.Errorf("high risk = %d, want 1", summary.HighRiskCount)
+	}
+
+	if len(summary.Annotations) == 0 {
+		t.Fatal("no fleet annotation usage")
+	}
+	top := summary.Annotations[0]
+	if top.Key != "nginx.ingress.kubernetes.io/ssl-redirect" || top.UsageCount != 3 ||
+		!reflect.DeepEqual(top.Clusters, []string{"prod", "staging"}) {
+		t.Errorf("top annotation = %+v, want ssl-redirect used 3 times in prod and staging", top)
+	}
+}
+
+func TestInventoryAndFleetEvaluateValueConditions(t *testing.T) {
+	analysis := func(annotations map[string]string) models.IngressAnalysis {
+		return models.IngressAnalysis{Resource: models.IngressResource{Namespace: "default", Annotations: annotations}}
+	}
+	protocol := "nginx.ingress.kubernetes.io/backend-protocol"
+	modsecurity := "nginx.ingress.kubernetes.io/enable-modsecurity"
+
+	inventory := BuildAnnotationInventory([]models.IngressAnalysis{
+		analysis(map[string]string{protocol: "HTTP", modsecurity: "false"}),
+		analysis(map[string]string{protocol: "GRPC", modsecurity: "false"}),
+		analysis(map[string]string{protocol: "HTTP"}),
+	})
+
+	if usage := inventory.NginxAnnotations[protocol]; usage.Risk != models.RiskManual || !strings.Contains(usage.MigrationNote, "GRPCRoute") {
+		t.Errorf("backend-protocol = %s %q, want MANUAL from the GRPC value", usage.Risk, usage.MigrationNote)
+	}
+	if usage := inventory.NginxAnnotations[modsecurity]; usage.Risk != models.RiskAuto {
+		t.Errorf("enable-modsecurity = %s, want AUTO when it is always off", usage.Risk)
+	}
+
+	summary := BuildFleetSummary([]models.ClusterResult{{Context: "prod", Analysis: &models.ClusterAnalysis{
+		Analyses: []models.IngressAnalysis{analysis(map[string]string{protocol: "HTTP", modsecurity: "false"})},
+	}}})
+	for _, annotation := range summary.Annotations {
+		if annotation.Risk != models.RiskAuto {
+			t.Errorf("fleet %s = %s, want AUTO", annotation.Key, annotation.Risk)
+		}
+	}
+}
@@ -8,45 +8,15 @@ import (
 	"ingress-migration-analyzer/pkg/rules"
 )
 
-// AnnotationUsage tracks how an annotation is used across the cluster
-type AnnotationUsage struct {
-	Key           string            `json:"key"`
-	UniqueValues  []string          `json:"uniqueValues"`
-	UsageCount    int               `json:"usageCount"`
-	Namespaces    []string          `json:"namespaces"`
-	ValueExamples map[string]int    `json:"valueExamples"` // value -> count
-	Risk          models.RiskLevel  `json:"risk"`
-	Description   string            `json:"description"`
-	MigrationNote string            `json:"migrationNote"`
-	SourceURL     string            `json:"sourceUrl"`
-}
-
-// AnnotationInventory provides comprehensive annotation analysis
-type AnnotationInventory struct {
-	AllAnnotations     map[string]*AnnotationUsage `json:"allAnnotations"`
-	NginxAnnotations   map[string]*AnnotationUsage `json:"nginxAnnotations"`
-	UnknownAnnotations map[string]*AnnotationUsage `json:"unknownAnnotations"`
-	Summary           InventorySummary            `json:"summary"`
-}
-
-// InventorySummary provides high-level inventory statistics
-type InventorySummary struct {
-	TotalUniqueAnnotations    int `json:"totalUniqueAnnotations"`
-	NginxAnnotationsCount     int `json:"nginxAnnotationsCount"`
-	UnknownAnnotationsCount   int `json:"unknownAnnotationsCount"`
-	MostUsedAnnotation       string `json:"mostUsedAnnotation"`
-	MostComplexNamespace     string `json:"mostComplexNamespace"`
-}
-
 // BuildAnnotationInventory creates comprehensive annotation usage analysis
 // by processing all ingress analyses and categorizing annotations by usage
 // patterns, risk levels, and migration complexity. System annotations are
 // automatically filtered out from the analysis.
-func BuildAnnotationInventory(analyses []models.IngressAnalysis) *AnnotationInventory {
-	inventory := &AnnotationInventory{
-		AllAnnotations:     make(map[string]*AnnotationUsage),
-		NginxAnnotations:   make(map[string]*AnnotationUsage),
-		UnknownAnnotations: make(map[string]*AnnotationUsage),
+func BuildAnnotationInventory(analyses []models.IngressAnalysis) *models.AnnotationInventory {
+	inventory := &models.AnnotationInventory{
+		AllAnnotations:     make(map[string]*models.AnnotationUsage),
+		NginxAnnotations:   make(map[string]*models.AnnotationUsage),
+		UnknownAnnotations: make(map[string]*models.AnnotationUsage),
 	}
 
 	// Process each ingress analysis
@@ -60,18 +30,21 @@ func BuildAnnotationInventory(analyses []models.IngressAnalysis) *AnnotationInve
 			usage := getOrCreateUsage(inventory.AllAnnotations, key)
 			updateUsage(usage, value, analysis.Resource.Namespace)
 
-			// Categorize nginx annotations
-			if strings.HasPrefix(key, "nginx.ingress.kubernetes.io/") {
-				nginxUsage := getOrCreateUsage(inventory.NginxAnnotations, key)
+			// Categorize nginx annotations under the default prefix so that
+			// controllers with a custom --annotation-prefix are aggregated
+			if canonicalKey, ok := rules.CanonicalAnnotationKey(key, analysis.Resource.AnnotationPrefix); ok {
+				nginxUsage := getOrCreateUsage(inventory.NginxAnnotations, canonicalKey)
 				updateUsage(nginxUsage, value, analysis.Resource.Namespace)
 				
-				// Add risk and migration info
-				if rule := rules.GetRuleByPattern(key); rule != nil {
+				// Add risk and migration info of the riskiest value seen
+				rule := rules.GetRuleForAnnotationValue(canonicalKey, value)
+				switch {
+				case rule != nil && (nginxUsage.Risk == "" || rules.HigherRisk(rule.RiskLevel, nginxUsage.Risk)):
 					nginxUsage.Risk = rule.RiskLevel
 					nginxUsage.Description = rule.Description
 					nginxUsage.MigrationNote = rule.MigrationNote
 					nginxUsage.SourceURL = rule.SourceURL
-				} else {
+				case rule == nil:
 					nginxUsage.Risk = models.RiskLevel("UNKNOWN")
 					nginxUsage.Description = "Unknown nginx annotation - not in current knowledge base"
 					nginxUsage.MigrationNote = "This annotation is not documented in our migration rules. Please research Gateway API equivalent or file an issue."
@@ -95,12 +68,12 @@ func BuildAnnotationInventory(analyses []models.IngressAnalysis) *AnnotationInve
 }
 
 // getOrCreateUsage gets existing usage or creates new one
-func getOrCreateUsage(usageMap map[string]*AnnotationUsage, key string) *AnnotationUsage {
+func getOrCreateUsage(usageMap map[string]*models.AnnotationUsage, key string) *models.AnnotationUsage {
 	if usage, exists := usageMap[key]; exists {
 		return usage
 	}
 
-	usage := &AnnotationUsage{
+	usage := &models.AnnotationUsage{
 		Key:           key,
 		UniqueValues:  []string{},
 		UsageCount:    0,
@@ -112,7 +85,7 @@ func getOrCreateUsage(usageMap map[string]*AnnotationUsage, key string) *Annotat
 }
 
 // updateUsage updates usage statistics
-func updateUsage(usage *AnnotationUsage, value, namespace string) {
+func updateUsage(usage *models.AnnotationUsage, value, namespace string) {
 	usage.UsageCount++
 
 	// Track unique values
@@ -144,8 +117,8 @@ func updateUsage(usage *AnnotationUsage, value, namespace string) {
 }
 
 // generateInventorySummary creates summary statistics
-func generateInventorySummary(inventory *AnnotationInventory) InventorySummary {
-	summary := InventorySummary{
+func generateInventorySummary(inventory *models.AnnotationInventory) models.InventorySummary {
+	summary := models.InventorySummary{
 		TotalUniqueAnnotations:  len(inventory.AllAnnotations),
 		NginxAnnotationsCount:   len(inventory.NginxAnnotations),
 		UnknownAnnotationsCount: len(inventory.UnknownAnnotations),
@@ -165,8 +138,8 @@ func generateInventorySummary(inventory *AnnotationInventory) InventorySummary {
 
 // GetAnnotationsByRisk returns annotations grouped by risk level,
 // sorted by usage count within each risk level for prioritization.
-func (inv *AnnotationInventory) GetAnnotationsByRisk() map[models.RiskLevel][]*AnnotationUsage {
-	byRisk := make(map[models.RiskLevel][]*AnnotationUsage)
+func GetAnnotationsByRisk(inv *models.AnnotationInventory) map[models.RiskLevel][]*models.AnnotationUsage {
+	byRisk := make(map[models.RiskLevel][]*models.AnnotationUsage)
 	
 	for _, usage := range inv.NginxAnnotations {
 		byRisk[usage.Risk] = append(byRisk[usage.Risk], usage)
@@ -185,8 +158,8 @@ func (inv *AnnotationInventory) GetAnnotationsByRisk() map[models.RiskLevel][]*A
 // GetMostCriticalAnnotations returns the most problematic annotations for migration,
 // including high-risk and unknown annotations, sorted by usage frequency to prioritize
 // the most impactful migration decisions.
-func (inv *AnnotationInventory) GetMostCriticalAnnotations(limit int) []*AnnotationUsage {
-	var critical []*AnnotationUsage
+func GetMostCriticalAnnotations(inv *models.AnnotationInventory, limit int) []*models.AnnotationUsage {
+	var critical []*models.AnnotationUsage
 
 	// Collect high-risk annotations
 	for _, usage := range inv.NginxAnnotations {
@@ -0,0 +1,165 @@
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/discovery"
+	"ingress-migration-analyzer/pkg/rules"
+)
+
+// watchState is the last known analysis of a watched object
+type watchState struct {
+	risk     models.RiskLevel
+	patterns []string // patterns of the matched rules, sorted
+}
+
+// Watch keeps the cluster under watch and re-analyzes each Ingress and
+// controller ConfigMap when it changes. Once the initial state has been
+// analyzed, onDelta is called for every change in risk level or matched
+// rules. Watch blocks until ctx is done.
+func (a *Analyzer) Watch(ctx context.Context, onDelta func(models.RiskDelta)) error {
+	ingresses := make(map[string]models.IngressAnalysis)
+	ingressStates := make(map[string]watchState)
+	configStates := make(map[string]watchState)
+	synced := false
+
+	update := func(kind string, states map[string]watchState, key string, current *watchState) {
+		previous, existed := states[key]
+		if current != nil {
+			states[key] = *current
+		} else {
+			delete(states, key)
+		}
+
+		if !synced {
+			return
+		}
+		if delta, changed := riskDelta(kind, key, previous, existed, current); changed {
+			onDelta(delta)
+		}
+	}
+
+	handlers := discovery.WatchHandlers{
+		Ingress: func(key string, resource *models.IngressResource) {
+			if resource == nil {
+				delete(ingresses, key)
+				update("Ingress", ingressStates, key, nil)
+				return
+			}
+			analysis := a.analyzeIngress(*resource)
+			ingresses[key] = analysis
+			update("Ingress", ingressStates, key, &watchState{
+				risk:     analysis.RiskLevel,
+				patterns: rulePatterns(analysis.MatchedRules),
+			})
+		},
+		ControllerConfig: func(config models.ControllerConfig) {
+			matchedRules := rules.MatchConfigMapSettings(config.Data)
+			update("ConfigMap", configStates, config.ConfigMap, &watchState{
+				risk:     rules.GetHighestRiskLevel(matchedRules),
+				patterns: rulePatterns(matchedRules),
+			})
+		},
+		Synced: func() {
+			synced = true
+			analyses := make([]models.IngressAnalysis, 0, len(ingresses))
+			for _, analysis := range ingresses {
+				analyses = append(analyses, analysis)
+			}
+			if len(analyses) > 0 {
+				a.printAnalysisSummary(a.generateSummary(analyses))
+			}
+			fmt.Printf("\n👀 Watching %d ingress-nginx resources for changes (Ctrl+C to stop)...\n", len(analyses))
+		},
+	}
+
+	return a.scanner.Watch(ctx, handlers)
+}
+
+// riskDelta compares two states of an object and reports whether its
+// risk level or matched rules changed
+func riskDelta(kind, key string, previous watchState, existed bool, current *watchState) (models.RiskDelta, bool) {
+	delta := models.RiskDelta{
+		Time: time.Now(),
+		Kind: kind,
+		Key:  key,
+	}
+
+	var currentPatterns []string
+	if existed {
+		delta.Previous = previous.risk
+	}
+	if current != nil {
+		delta.Current = current.risk
+		currentPatterns = current.patterns
+	}
+	delta.Added = difference(currentPatterns, previous.patterns)
+	delta.Removed = difference(previous.patterns, currentPatterns)
+
+	changed := existed != (current != nil) || delta.Previous != delta.Current ||
+		len(delta.Added) > 0 || len(delta.Removed) > 0
+	return delta, changed
+}
+
+// FormatRiskDelta describes a risk delta in one line, such as
+// "team-x/api went MANUAL -> HIGH_RISK (+configuration-snippet)"
+func FormatRiskDelta(delta models.RiskDelta) string {
+	var line string
+	switch {
+	case delta.Previous == "":
+		line = fmt.Sprintf("%s %s %s added as %s", GetRiskLevelIcon(delta.Current), delta.Kind, delta.Key, delta.Current)
+	case delta.Current == "":
+		line = fmt.Sprintf("🗑️  %s %s removed (was %s)", delta.Kind, delta.Key, delta.Previous)
+	case delta.Previous != delta.Current:
+		line = fmt.Sprintf("%s %s %s went %s -> %s", GetRiskLevelIcon(delta.Current), delta.Kind, delta.Key, delta.Previous, delta.Current)
+	default:
+		line = fmt.Sprintf("%s %s %s still %s", GetRiskLevelIcon(delta.Current), delta.Kind, delta.Key, delta.Current)
+	}
+
+	var changes []string
+	for _, pattern := range delta.Added {
+		changes = append(changes, "+"+pattern)
+	}
+	// Rules of a removed object are implied by the removal
+	if delta.Current != "" {
+		for _, pattern := range delta.Removed {
+			changes = append(changes, "-"+pattern)
+		}
+	}
+	if len(changes) > 0 {
+		line += fmt.Sprintf(" (%s)", strings.Join(changes, ", "))
+	}
+
+	return line
+}
+
+// rulePatterns returns the sorted patterns of matched rules
+func rulePatterns(matchedRules []models.AnnotationRule) []string {
+	patterns := make([]string, 0, len(matchedRules))
+	for _, rule := range matchedRules {
+		patterns = append(patterns, rule.Pattern)
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// difference returns the elements of a that are not in b
+func difference(a, b []string) []string {
+	seen := make(map[string]bool, len(b))
+	for _, s := range b {
+		seen[s] = true
+	}
+
+	var diff []string
+	for _, s := range a {
+		if !seen[s] {
+			diff = append(diff, s)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,33 @@
+package analyze
+
+import (
+	"testing"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+func TestRiskDelta(t *testing.T) {
+	previous := watchState{risk: models.RiskManual, patterns: []string{"nginx.ingress.kubernetes.io/proxy-body-size"}}
+	current := &watchState{risk: models.RiskHigh, patterns: []string{
+		"nginx.ingress.kubernetes.io/configuration-snippet",
+		"nginx.ingress.kubernetes.io/proxy-body-size",
+	}}
+
+	delta, changed := riskDelta("Ingress", "team-x/api", previous, true, current)
+	if !changed {
+		t.Fatal("expected a change")
+	}
+	want := "❌ Ingress team-x/api went MANUAL -> HIGH_RISK (+nginx.ingress.kubernetes.io/configuration-snippet)"
+	if got := FormatRiskDelta(delta); got != want {
+		t.Errorf("FormatRiskDelta() = %q, want %q", got, want)
+	}
+
+	if _, changed := riskDelta("Ingress", "team-x/api", *current, true, current); changed {
+		t.Error("expected no change for an identical state")
+	}
+
+	delta, changed = riskDelta("Ingress", "team-x/api", *current, true, nil)
+	if !changed || delta.Current != "" || delta.Previous != models.RiskHigh {
+		t.Errorf("removal delta = %+v, changed = %v", delta, changed)
+	}
+}
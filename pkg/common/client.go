@@ -18,14 +18,14 @@ type ClientConfig struct {
 // CreateAnalyzerClient creates a Kubernetes client with validation.
 // It first validates the connection and context, then creates the client.
 // This function consolidates shared client creation logic across commands.
-func CreateAnalyzerClient(kubeconfig, contextName string) (*discovery.Client, error) {
+func CreateAnalyzerClient(kubeconfig, contextName string, options discovery.ClientOptions) (*discovery.Client, error) {
 	// Test Kubernetes connection first
-	if err := discovery.ValidateConnection(kubeconfig, contextName); err != nil {
+	if err := discovery.ValidateConnection(kubeconfig, contextName, options); err != nil {
 		return nil, fmt.Errorf("connection failed: %w", err)
 	}
 
 	// Create Kubernetes client
-	client, err := discovery.NewClient(kubeconfig, contextName)
+	client, err := discovery.NewClient(kubeconfig, contextName, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
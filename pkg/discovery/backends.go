@@ -0,0 +1,203 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// extractBackends extracts every backend an Ingress references, in spec
+// order, without duplicates
+func extractBackends(ingress networkingv1.Ingress) []models.Backend {
+	var backends []models.Backend
+	seen := make(map[string]bool)
+
+	add := func(backend networkingv1.IngressBackend, isDefault bool) {
+		ref, ok := newBackendRef(backend)
+		if !ok {
+			return
+		}
+		b := models.Backend{BackendRef: ref, Default: isDefault}
+		key := fmt.Sprintf("%s|%s|%s|%t", b.Service, b.Port, b.Resource, b.Default)
+		if !seen[key] {
+			seen[key] = true
+			backends = append(backends, b)
+		}
+	}
+
+	if ingress.Spec.DefaultBackend != nil {
+		add(*ingress.Spec.DefaultBackend, true)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			add(path.Backend, false)
+		}
+	}
+
+	return backends
+}
+
+// newBackendRef converts an Ingress backend as written, reporting false
+// when it names neither a Service nor a resource
+func newBackendRef(backend networkingv1.IngressBackend) (models.BackendRef, bool) {
+	var ref models.BackendRef
+	switch {
+	case backend.Service != nil:
+		ref.Service = backend.Service.Name
+		if backend.Service.Port.Name != "" {
+			ref.Port = backend.Service.Port.Name
+		} else {
+			ref.Port = strconv.Itoa(int(backend.Service.Port.Number))
+		}
+	case backend.Resource != nil:
+		ref.Resource = backend.Resource.Kind + "/" + backend.Resource.Name
+	default:
+		return ref, false
+	}
+	return ref, true
+}
+
+// backendResolver looks up backend Services and their EndpointSlices,
+// caching lookups across Ingresses
+type backendResolver struct {
+	scanner   *Scanner
+	services  map[string]*corev1.Service // namespace/name -> Service, nil when missing
+	errors    map[string]error
+	endpoints map[string]int // namespace/name -> ready endpoints
+}
+
+// resolveBackends resolves the backends of every Ingress against the cluster
+func (s *Scanner) resolveBackends(ctx context.Context, resources []models.IngressResource) {
+	resolver := &backendResolver{
+		scanner:   s,
+		services:  make(map[string]*corev1.Service),
+		errors:    make(map[string]error),
+		endpoints: make(map[string]int),
+	}
+
+	for i := range resources {
+		for j := range resources[i].Backends {
+			resolver.resolve(ctx, resources[i].Namespace, &resources[i].Backends[j])
+		}
+	}
+}
+
+// resolve resolves a single backend and records any issues with it
+func (r *backendResolver) resolve(ctx context.Context, namespace string, backend *models.Backend) {
+	if backend.Resource != "" {
+		backend.Issues = append(backend.Issues,
+			fmt.Sprintf("resource backend %s has no HTTPRoute backendRef equivalent", backend.Resource))
+		return
+	}
+
+	service, err := r.service(ctx, namespace, backend.Service)
+	if err != nil {
+		backend.Issues = append(backend.Issues, fmt.Sprintf("could not read Service: %v", err))
+		return
+	}
+	backend.Resolved = true
+	if service == nil {
+		backend.Issues = append(backend.Issues, fmt.Sprintf("Service %s not found", backend.Service))
+		return
+	}
+	backend.ServiceType = string(service.Spec.Type)
+
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		backend.ExternalName = service.Spec.ExternalName
+		backend.Issues = append(backend.Issues, fmt.Sprintf(
+			"ExternalName Service (%s): most Gateway implementations reject ExternalName backendRefs; "+
+				"use a Service with endpoints or an implementation-specific backend", service.Spec.ExternalName))
+		return
+	}
+
+	port := findServicePort(service.Spec.Ports, backend.Port)
+	if port == nil {
+		backend.Issues = append(backend.Issues, fmt.Sprintf("Service %s has no port %s", backend.Service, backend.Port))
+		return
+	}
+	backend.PortNumber = port.Port
+	backend.TargetPort = port.TargetPort.String()
+	if backend.Port != strconv.Itoa(int(port.Port)) {
+		backend.Issues = append(backend.Issues, fmt.Sprintf(
+			"port referenced by name %q; HTTPRoute backendRefs need the port number %d", backend.Port, port.Port))
+	}
+
+	ready, err := r.readyEndpoints(ctx, namespace, backend.Service)
+	if err != nil {
+		backend.Issues = append(backend.Issues, fmt.Sprintf("could not read EndpointSlices: %v", err))
+		return
+	}
+	backend.ReadyEndpoints = ready
+	if ready == 0 {
+		backend.Issues = append(backend.Issues, fmt.Sprintf("Service %s has no ready endpoints", backend.Service))
+	}
+}
+
+// service returns a Service, or nil when it does not exist
+func (r *backendResolver) service(ctx context.Context, namespace, name string) (*corev1.Service, error) {
+	key := namespace + "/" + name
+	if err, failed := r.errors[key]; failed {
+		return nil, err
+	}
+	if service, cached := r.services[key]; cached {
+		return service, nil
+	}
+
+	service, err := callAPI(ctx, func(ctx context.Context) (*corev1.Service, error) {
+		return r.scanner.client.Clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if apierrors.IsNotFound(err) {
+		r.services[key] = nil
+		return nil, nil
+	}
+	if err != nil {
+		r.scanner.recordError(models.StageBackends, namespace, err, "backend Services of Ingresses in this namespace were not resolved")
+		r.errors[key] = err
+		return nil, err
+	}
+
+	r.services[key] = service
+	return service, nil
+}
+
+// readyEndpoints counts the ready endpoints across a Service's EndpointSlices
+func (r *backendResolver) readyEndpoints(ctx context.Context, namespace, name string) (int, error) {
+	key := namespace + "/" + name
+	if ready, cached := r.endpoints[key]; cached {
+		return ready, nil
+	}
+
+	slices, err := callAPI(ctx, func(ctx context.Context) (*discoveryv1.EndpointSliceList, error) {
+		return r.scanner.client.Clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+		})
+	})
+	if err != nil {
+		r.scanner.recordError(models.StageBackends, namespace, err, "backend Services of Ingresses in this namespace were not resolved")
+		return 0, err
+	}
+
+	var ready int
+	for _, slice := range slices.Items {
+		for _, endpoint := range slice.Endpoints {
+			// A nil Ready condition means unknown, which consumers treat as ready
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready++
+			}
+		}
+	}
+
+	r.endpoints[key] = ready
+	return ready, nil
+}
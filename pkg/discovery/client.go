@@ -1,28 +1,36 @@
 package discovery
 
 import (
-	"context"
 	"fmt"
 	"os"
-	"path/filepath"
+	"sort"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 // Client wraps the Kubernetes client with connection info
 type Client struct {
-	Clientset      *kubernetes.Clientset
+	Clientset      kubernetes.Interface
 	Config         *rest.Config
 	ClusterVersion string
 	Context        string
 }
 
+// ClientOptions overrides who the client authenticates as and which API
+// server it talks to, like kubectl's --as, --as-group, --token and --server
+type ClientOptions struct {
+	Impersonate       string
+	ImpersonateGroups []string
+	Token             string
+	Server            string
+}
+
 // NewClient creates a new Kubernetes client
-func NewClient(kubeconfigPath, contextName string) (*Client, error) {
-	config, err := loadConfig(kubeconfigPath, contextName)
+func NewClient(kubeconfigPath, contextName string, options ClientOptions) (*Client, error) {
+	config, err := loadConfig(kubeconfigPath, contextName, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
@@ -46,31 +54,33 @@ func NewClient(kubeconfigPath, contextName string) (*Client, error) {
 	return client, nil
 }
 
-// loadConfig loads the Kubernetes configuration from kubeconfig
-func loadConfig(kubeconfigPath, contextName string) (*rest.Config, error) {
-	// First try in-cluster config if no kubeconfig path is provided
-	if kubeconfigPath == "" {
+// loadConfig loads the Kubernetes configuration and applies the client
+// options. Without a kubeconfig path or $KUBECONFIG, the in-cluster
+// config is used when running in a Pod, else ~/.kube/config.
+func loadConfig(kubeconfigPath, contextName string, options ClientOptions) (*rest.Config, error) {
+	if kubeconfigPath == "" && contextName == "" && os.Getenv(clientcmd.RecommendedConfigPathEnvVar) == "" {
 		if config, err := rest.InClusterConfig(); err == nil {
+			options.apply(config)
 			return config, nil
 		}
 	}
 
-	// Fall back to kubeconfig file
-	if kubeconfigPath == "" {
-		kubeconfigPath = getDefaultKubeconfigPath()
-	}
-
-	// Check if kubeconfig file exists
-	if _, err := os.Stat(kubeconfigPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("kubeconfig file not found at %s", kubeconfigPath)
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfigPath != "" {
+		// Check if kubeconfig file exists
+		if _, err := os.Stat(kubeconfigPath); os.IsNotExist(err) {
+			return nil, fmt.Errorf("kubeconfig file not found at %s", kubeconfigPath)
+		}
+		loadingRules.ExplicitPath = kubeconfigPath
 	}
 
-	// Load the kubeconfig file
-	configLoader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath},
-		&clientcmd.ConfigOverrides{CurrentContext: contextName},
-	)
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: contextName}
+	overrides.AuthInfo.Impersonate = options.Impersonate
+	overrides.AuthInfo.ImpersonateGroups = options.ImpersonateGroups
+	overrides.AuthInfo.Token = options.Token
+	overrides.ClusterInfo.Server = options.Server
 
+	configLoader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
 	config, err := configLoader.ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load client config: %w", err)
@@ -79,18 +89,35 @@ func loadConfig(kubeconfigPath, contextName string) (*rest.Config, error) {
 	return config, nil
 }
 
-// getDefaultKubeconfigPath returns the default kubeconfig path
-func getDefaultKubeconfigPath() string {
-	if home, err := os.UserHomeDir(); err == nil {
-		return filepath.Join(home, ".kube", "config")
+// apply applies the options to an in-cluster config
+func (o ClientOptions) apply(config *rest.Config) {
+	if o.Server != "" {
+		config.Host = o.Server
+	}
+	if o.Token != "" {
+		config.BearerToken = o.Token
+		config.BearerTokenFile = ""
+	}
+	if o.Impersonate != "" || len(o.ImpersonateGroups) > 0 {
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: o.Impersonate,
+			Groups:   o.ImpersonateGroups,
+		}
 	}
-	return ""
 }
 
-// verifyConnection tests the connection and retrieves cluster info
-func (c *Client) verifyConnection() error {
-	ctx := context.Background()
+// loadKubeconfig loads kubeconfigPath, or the kubeconfig files from
+// $KUBECONFIG or ~/.kube/config when it is empty
+func loadKubeconfig(kubeconfigPath string) (*clientcmdapi.Config, error) {
+	if kubeconfigPath != "" {
+		return clientcmd.LoadFromFile(kubeconfigPath)
+	}
+	return clientcmd.NewDefaultClientConfigLoadingRules().Load()
+}
 
+// verifyConnection tests the connection and retrieves cluster info. RBAC
+// permissions are checked separately with CheckPermissions.
+func (c *Client) verifyConnection() error {
 	// Get server version to verify connection
 	serverVersion, err := c.Clientset.Discovery().ServerVersion()
 	if err != nil {
@@ -99,13 +126,6 @@ func (c *Client) verifyConnection() error {
 
 	c.ClusterVersion = serverVersion.GitVersion
 
-	// Test basic API access
-	listOptions := metav1.ListOptions{Limit: 1}
-	_, err = c.Clientset.CoreV1().Namespaces().List(ctx, listOptions)
-	if err != nil {
-		return fmt.Errorf("failed to list namespaces (check your RBAC permissions): %w", err)
-	}
-
 	return nil
 }
 
@@ -115,8 +135,8 @@ func (c *Client) GetClusterInfo() (string, string, error) {
 }
 
 // ValidateConnection performs a basic connectivity check
-func ValidateConnection(kubeconfigPath, contextName string) error {
-	client, err := NewClient(kubeconfigPath, contextName)
+func ValidateConnection(kubeconfigPath, contextName string, options ClientOptions) error {
+	client, err := NewClient(kubeconfigPath, contextName, options)
 	if err != nil {
 		return err
 	}
@@ -136,11 +156,7 @@ func ValidateConnection(kubeconfigPath, contextName string) error {
 
 // ListAvailableContexts lists available contexts from kubeconfig
 func ListAvailableContexts(kubeconfigPath string) ([]string, error) {
-	if kubeconfigPath == "" {
-		kubeconfigPath = getDefaultKubeconfigPath()
-	}
-
-	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	config, err := loadKubeconfig(kubeconfigPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
@@ -149,17 +165,14 @@ func ListAvailableContexts(kubeconfigPath string) ([]string, error) {
 	for name := range config.Contexts {
 		contexts = append(contexts, name)
 	}
+	sort.Strings(contexts)
 
 	return contexts, nil
 }
 
 // GetCurrentContext returns the current context from kubeconfig
 func GetCurrentContext(kubeconfigPath string) (string, error) {
-	if kubeconfigPath == "" {
-		kubeconfigPath = getDefaultKubeconfigPath()
-	}
-
-	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	config, err := loadKubeconfig(kubeconfigPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
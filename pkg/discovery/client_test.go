@@ -0,0 +1,79 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: admin
+clusters:
+- name: prod
+  cluster:
+    server: https://prod.example.com:6443
+users:
+- name: admin
+  user:
+    token: admin-token
+contexts:
+- name: admin
+  context:
+    cluster: prod
+    user: admin
+`
+
+func TestLoadConfigAppliesClientOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := loadConfig(path, "", ClientOptions{})
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	if config.Host != "https://prod.example.com:6443" || config.BearerToken != "admin-token" || config.Impersonate.UserName != "" {
+		t.Errorf("without options: host %q, token %q, impersonate %+v", config.Host, config.BearerToken, config.Impersonate)
+	}
+
+	config, err = loadConfig(path, "", ClientOptions{
+		Impersonate:       "system:serviceaccount:team-x:deployer",
+		ImpersonateGroups: []string{"system:serviceaccounts:team-x"},
+		Token:             "override-token",
+		Server:            "https://proxy.example.com",
+	})
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	if config.Host != "https://proxy.example.com" || config.BearerToken != "override-token" {
+		t.Errorf("host %q and token %q, want the overrides", config.Host, config.BearerToken)
+	}
+	if config.Impersonate.UserName != "system:serviceaccount:team-x:deployer" ||
+		!reflect.DeepEqual(config.Impersonate.Groups, []string{"system:serviceaccounts:team-x"}) {
+		t.Errorf("impersonate = %+v, want the service account and its group", config.Impersonate)
+	}
+}
+
+func TestLoadConfigUsesKubeconfigEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", path)
+
+	config, err := loadConfig("", "", ClientOptions{})
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	if config.Host != "https://prod.example.com:6443" {
+		t.Errorf("host = %q, want the server from $KUBECONFIG", config.Host)
+	}
+
+	contexts, err := ListAvailableContexts("")
+	if err != nil || !reflect.DeepEqual(contexts, []string{"admin"}) {
+		t.Errorf("ListAvailableContexts() = %v, %v; want [admin]", contexts, err)
+	}
+}
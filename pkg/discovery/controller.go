@@ -0,0 +1,366 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/rules"
+)
+
+// controllerBinary is the entrypoint of the ingress-nginx controller image
+const controllerBinary = "/nginx-ingress-controller"
+
+// defaultIngressClass is the controller's --ingress-class default
+const defaultIngressClass = "nginx"
+
+// controllerWorkload is a Deployment or DaemonSet running the ingress-nginx controller
+type controllerWorkload struct {
+	kind      string
+	namespace string
+	name      string
+	template  corev1.PodTemplateSpec
+	replicas  int32
+	ready     int32
+}
+
+// findControllers finds ingress-nginx controller Deployments and DaemonSets,
+// describes each one, and reads the ConfigMap it references with --configmap
+func (s *Scanner) findControllers(ctx context.Context) ([]models.IngressController, []models.ControllerConfig, error) {
+	workloads, err := s.listControllerWorkloads(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].namespace != workloads[j].namespace {
+			return workloads[i].namespace < workloads[j].namespace
+		}
+		return workloads[i].name < workloads[j].name
+	})
+
+	var controllers []models.IngressController
+	var configs []models.ControllerConfig
+	services := make(map[string][]corev1.Service)
+	for _, workload := range workloads {
+		container := findControllerContainer(workload.template.Spec)
+		args := controllerArgs(*container)
+
+		if _, listed := services[workload.namespace]; !listed {
+			services[workload.namespace], err = s.listServices(ctx, workload.namespace)
+			if err != nil {
+				s.recordError(models.StageControllerServices, workload.namespace, err, "the Service type of controllers in this namespace is unknown")
+			}
+		}
+		controllers = append(controllers, describeController(workload, container.Image, args, services[workload.namespace]))
+
+		config := models.ControllerConfig{
+			Namespace: workload.namespace,
+			Name:      workload.name,
+			ConfigMap: resolveConfigMapRef(flagValue(args, "configmap"), workload.namespace),
+		}
+		if config.ConfigMap != "" {
+			data, err := s.readConfigMap(ctx, config.ConfigMap)
+			if err != nil {
+				config.Error = err.Error()
+			}
+			config.Data = data
+		}
+		config.StreamServices = s.findStreamServices(ctx, args, workload.namespace)
+		configs = append(configs, config)
+	}
+
+	return controllers, configs, nil
+}
+
+// attributeControllers records which controllers serve each Ingress. A
+// controller serves an Ingress whose IngressClass names its --controller-class
+// (or, without IngressClass objects, whose class equals its --ingress-class),
+// class-less Ingresses when --watch-ingress-without-class is set, and only
+// Ingresses in --watch-namespace when that is set.
+func attributeControllers(resources []models.IngressResource, controllers []models.IngressController) {
+	for i := range resources {
+		resource := &resources[i]
+		for _, controller := range controllers {
+			if controller.WatchNamespace != "" && !containsNamespace(controller.WatchNamespace, resource.Namespace) {
+				continue
+			}
+
+			var serves bool
+			switch {
+			case resource.Controller != "":
+				serves = controller.ControllerClass == resource.Controller
+			case resource.ResolvedClass != "":
+				serves = controller.IngressClass == resource.ResolvedClass
+			default:
+				serves = controller.WatchWithoutClass
+			}
+			if serves {
+				resource.ServedBy = append(resource.ServedBy, controller.Namespace+"/"+controller.Name)
+			}
+		}
+	}
+}
+
+// assignAnnotationPrefixes sets the annotation prefix each Ingress is read
+// with: the --annotation-prefix override, else the prefix of the controller
+// serving it, else whichever known prefix its annotations use
+func assignAnnotationPrefixes(resources []models.IngressResource, controllers []models.IngressController, override string, prefixes []string) {
+	controllerPrefixes := make(map[string]string)
+	for _, controller := range controllers {
+		controllerPrefixes[controller.Namespace+"/"+controller.Name] = controller.AnnotationPrefix
+	}
+
+	for i := range resources {
+		resource := &resources[i]
+		switch {
+		case override != "":
+			resource.AnnotationPrefix = override
+		case len(resource.ServedBy) > 0:
+			resource.AnnotationPrefix = controllerPrefixes[resource.ServedBy[0]]
+		default:
+			resource.AnnotationPrefix = detectAnnotationPrefix(resource.Annotations, prefixes)
+		}
+	}
+}
+
+// detectAnnotationPrefix returns the first prefix used by any annotation,
+// or the default prefix
+func detectAnnotationPrefix(annotations map[string]string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		for key := range annotations {
+			if strings.HasPrefix(key, prefix+"/") {
+				return prefix
+			}
+		}
+	}
+	return rules.DefaultAnnotationPrefix
+}
+
+// containsNamespace reports whether a comma-separated --watch-namespace value includes namespace
+func containsNamespace(watchNamespace, namespace string) bool {
+	for _, ns := range strings.Split(watchNamespace, ",") {
+		if strings.TrimSpace(ns) == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// listControllerWorkloads lists Deployments and DaemonSets that run the
+// ingress-nginx controller
+func (s *Scanner) listControllerWorkloads(ctx context.Context) ([]controllerWorkload, error) {
+	var workloads []controllerWorkload
+
+	p := s.newPager(func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return s.client.Clientset.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, opts)
+	})
+	err := p.EachListItem(ctx, metav1.ListOptions{}, func(obj runtime.Object) error {
+		deployment := obj.(*appsv1.Deployment)
+		if findControllerContainer(deployment.Spec.Template.Spec) == nil {
+			return nil
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		workloads = append(workloads, controllerWorkload{
+			kind:      "Deployment",
+			namespace: deployment.Namespace,
+			name:      deployment.Name,
+			template:  deployment.Spec.Template,
+			replicas:  replicas,
+			ready:     deployment.Status.ReadyReplicas,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	p = s.newPager(func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return s.client.Clientset.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, opts)
+	})
+	err = p.EachListItem(ctx, metav1.ListOptions{}, func(obj runtime.Object) error {
+		daemonSet := obj.(*appsv1.DaemonSet)
+		if findControllerContainer(daemonSet.Spec.Template.Spec) == nil {
+			return nil
+		}
+		workloads = append(workloads, controllerWorkload{
+			kind:      "DaemonSet",
+			namespace: daemonSet.Namespace,
+			name:      daemonSet.Name,
+			template:  daemonSet.Spec.Template,
+			replicas:  daemonSet.Status.DesiredNumberScheduled,
+			ready:     daemonSet.Status.NumberReady,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+
+	return workloads, nil
+}
+
+// listServices lists the Services in a namespace
+func (s *Scanner) listServices(ctx context.Context, namespace string) ([]corev1.Service, error) {
+	services, err := callAPI(ctx, func(ctx context.Context) (*corev1.ServiceList, error) {
+		return s.client.Clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services in namespace %s: %w", namespace, err)
+	}
+	return services.Items, nil
+}
+
+// describeController builds the inventory entry for a controller workload
+func describeController(workload controllerWorkload, image string, args []string, services []corev1.Service) models.IngressController {
+	controller := models.IngressController{
+		Kind:              workload.kind,
+		Namespace:         workload.namespace,
+		Name:              workload.name,
+		Image:             image,
+		Version:           imageVersion(image),
+		Replicas:          workload.replicas,
+		ReadyReplicas:     workload.ready,
+		IngressClass:      flagValue(args, "ingress-class"),
+		ControllerClass:   flagValue(args, "controller-class"),
+		WatchNamespace:    flagValue(args, "watch-namespace"),
+		AnnotationPrefix:  flagValue(args, "annotation-prefix"),
+		SSLPassthrough:    boolFlag(args, "enable-ssl-passthrough"),
+		WatchWithoutClass: boolFlag(args, "watch-ingress-without-class"),
+	}
+	if controller.IngressClass == "" {
+		controller.IngressClass = defaultIngressClass
+	}
+	if controller.ControllerClass == "" {
+		controller.ControllerClass = NginxControllerName
+	}
+	if controller.AnnotationPrefix == "" {
+		controller.AnnotationPrefix = rules.DefaultAnnotationPrefix
+	}
+
+	// Prefer the most exposed Service selecting the controller pods; the
+	// admission webhook Service selects the same pods but is ClusterIP
+	rank := map[corev1.ServiceType]int{
+		corev1.ServiceTypeLoadBalancer: 3,
+		corev1.ServiceTypeNodePort:     2,
+		corev1.ServiceTypeClusterIP:    1,
+	}
+	for _, service := range services {
+		if len(service.Spec.Selector) == 0 {
+			continue
+		}
+		if !labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(workload.template.Labels)) {
+			continue
+		}
+		if rank[service.Spec.Type] > rank[corev1.ServiceType(controller.ServiceType)] {
+			controller.Service = service.Name
+			controller.ServiceType = string(service.Spec.Type)
+		}
+	}
+
+	return controller
+}
+
+// imageVersion extracts the tag from a container image reference
+func imageVersion(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	slash := strings.LastIndex(image, "/")
+	if colon := strings.LastIndex(image, ":"); colon > slash {
+		return image[colon+1:]
+	}
+	return ""
+}
+
+// readConfigMap reads the data of a ConfigMap given as namespace/name
+func (s *Scanner) readConfigMap(ctx context.Context, ref string) (map[string]string, error) {
+	namespace, name, found := strings.Cut(ref, "/")
+	if !found {
+		return nil, fmt.Errorf("invalid configmap reference %q", ref)
+	}
+
+	configMap, err := callAPI(ctx, func(ctx context.Context) (*corev1.ConfigMap, error) {
+		return s.client.Clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configmap %s: %w", ref, err)
+	}
+
+	return configMap.Data, nil
+}
+
+// findControllerContainer returns the ingress-nginx controller container of a pod spec
+func findControllerContainer(spec corev1.PodSpec) *corev1.Container {
+	for i, container := range spec.Containers {
+		args := controllerArgs(container)
+		if len(args) > 0 && args[0] == controllerBinary {
+			return &spec.Containers[i]
+		}
+		if strings.Contains(container.Image, "ingress-nginx/controller") {
+			return &spec.Containers[i]
+		}
+	}
+	return nil
+}
+
+// controllerArgs returns the full command line of a container
+func controllerArgs(container corev1.Container) []string {
+	args := append([]string{}, container.Command...)
+	return append(args, container.Args...)
+}
+
+// resolveConfigMapRef turns a ConfigMap flag value into namespace/name,
+// expanding $(POD_NAMESPACE) and defaulting the namespace to the controller's
+func resolveConfigMapRef(value, namespace string) string {
+	if value == "" {
+		return ""
+	}
+
+	value = strings.ReplaceAll(value, "$(POD_NAMESPACE)", namespace)
+	if !strings.Contains(value, "/") {
+		value = namespace + "/" + value
+	}
+	return value
+}
+
+// boolFlag reports whether a boolean controller flag is enabled
+func boolFlag(args []string, name string) bool {
+	for _, arg := range args {
+		trimmed := strings.TrimLeft(arg, "-")
+		if trimmed == arg {
+			continue
+		}
+		if trimmed == name || trimmed == name+"=true" {
+			return true
+		}
+	}
+	return false
+}
+
+// flagValue returns the value of a controller flag given as --name=value,
+// --name value, or the single-dash forms
+func flagValue(args []string, name string) string {
+	for i, arg := range args {
+		trimmed := strings.TrimLeft(arg, "-")
+		if trimmed == arg {
+			continue
+		}
+		if value, found := strings.CutPrefix(trimmed, name+"="); found {
+			return value
+		}
+		if trimmed == name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,180 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// ingressFilter selects the Ingresses that are scanned, by namespace and
+// by the Ingress's own name and labels
+type ingressFilter struct {
+	include  []string
+	exclude  []string
+	selected map[string]bool // namespaces matching the label selector, nil without one
+	names    []string
+	selector labels.Selector // nil without an Ingress label selector
+}
+
+// Validate checks the glob patterns and label selectors of the scan options
+func (o ScanOptions) Validate() error {
+	for _, pattern := range append(append([]string{}, o.IncludeNamespaces...), o.ExcludeNamespaces...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid namespace pattern %q: %w", pattern, err)
+		}
+	}
+	for _, pattern := range o.Names {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid name pattern %q: %w", pattern, err)
+		}
+	}
+	if o.NamespaceSelector != "" {
+		if _, err := labels.Parse(o.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid namespace selector %q: %w", o.NamespaceSelector, err)
+		}
+	}
+	if o.Selector != "" {
+		if _, err := labels.Parse(o.Selector); err != nil {
+			return fmt.Errorf("invalid selector %q: %w", o.Selector, err)
+		}
+	}
+	return nil
+}
+
+// newIngressFilter builds the Ingress filter from the scan options. A
+// namespace label selector is resolved by listing the matching namespaces.
+func (s *Scanner) newIngressFilter(ctx context.Context) (*ingressFilter, error) {
+	if err := s.options.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter := &ingressFilter{
+		include: s.options.IncludeNamespaces,
+		exclude: s.options.ExcludeNamespaces,
+		names:   s.options.Names,
+	}
+	if s.options.Selector != "" {
+		filter.selector, _ = labels.Parse(s.options.Selector)
+	}
+	if s.options.NamespaceSelector == "" {
+		return filter, nil
+	}
+	if s.client == nil {
+		return nil, fmt.Errorf("namespace selector %q requires cluster access", s.options.NamespaceSelector)
+	}
+
+	namespaces, err := s.listNamespaces(ctx, s.options.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces matching %q: %w", s.options.NamespaceSelector, err)
+	}
+	filter.selected = make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		filter.selected[ns] = true
+	}
+
+	return filter, nil
+}
+
+// ingressListOptions returns List options that apply the label selector,
+// and a single exact name, server-side. Name globs are matched client-side.
+func (s *Scanner) ingressListOptions() metav1.ListOptions {
+	opts := metav1.ListOptions{LabelSelector: s.options.Selector}
+	if len(s.options.Names) == 1 && !isGlob(s.options.Names[0]) {
+		opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", s.options.Names[0]).String()
+	}
+	return opts
+}
+
+// filters returns the filters of the scan for the scan result, or nil when
+// the scan covers all Ingresses
+func (s *Scanner) filters() *models.ScanFilters {
+	filters := models.ScanFilters{
+		Namespace:         s.namespace,
+		IncludeNamespaces: s.options.IncludeNamespaces,
+		ExcludeNamespaces: s.options.ExcludeNamespaces,
+		NamespaceSelector: s.options.NamespaceSelector,
+		Selector:          s.options.Selector,
+		Names:             s.options.Names,
+	}
+	if filters.Namespace == "" && len(filters.IncludeNamespaces) == 0 && len(filters.ExcludeNamespaces) == 0 &&
+		filters.NamespaceSelector == "" && filters.Selector == "" && len(filters.Names) == 0 {
+		return nil
+	}
+	return &filters
+}
+
+// active reports whether the filter excludes anything
+func (f *ingressFilter) active() bool {
+	return len(f.include) > 0 || len(f.exclude) > 0 || f.selected != nil || len(f.names) > 0 || f.selector != nil
+}
+
+// matches reports whether Ingresses in namespace should be scanned
+func (f *ingressFilter) matches(namespace string) bool {
+	if f.selected != nil && !f.selected[namespace] {
+		return false
+	}
+	if len(f.include) > 0 && !matchesAnyPattern(f.include, namespace) {
+		return false
+	}
+	return !matchesAnyPattern(f.exclude, namespace)
+}
+
+// matchesIngress reports whether an Ingress passes all filters
+func (f *ingressFilter) matchesIngress(ingress networkingv1.Ingress) bool {
+	if !f.matches(ingress.Namespace) {
+		return false
+	}
+	if len(f.names) > 0 && !matchesAnyPattern(f.names, ingress.Name) {
+		return false
+	}
+	return f.selector == nil || f.selector.Matches(labels.Set(ingress.Labels))
+}
+
+// filterNamespaces returns the namespaces that match the filter
+func (f *ingressFilter) filterNamespaces(namespaces []string) []string {
+	var matched []string
+	for _, ns := range namespaces {
+		if f.matches(ns) {
+			matched = append(matched, ns)
+		}
+	}
+	return matched
+}
+
+// filterIngresses returns the Ingresses that pass all filters
+func (f *ingressFilter) filterIngresses(ingresses []networkingv1.Ingress) []networkingv1.Ingress {
+	if !f.active() {
+		return ingresses
+	}
+
+	var matched []networkingv1.Ingress
+	for _, ingress := range ingresses {
+		if f.matchesIngress(ingress) {
+			matched = append(matched, ingress)
+		}
+	}
+	return matched
+}
+
+// matchesAnyPattern reports whether name matches any of the glob patterns
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isGlob reports whether pattern contains glob metacharacters
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
@@ -0,0 +1,162 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// NginxControllerName is the spec.controller value used by ingress-nginx IngressClasses
+	NginxControllerName = "k8s.io/ingress-nginx"
+
+	// defaultClassAnnotation marks the IngressClass used for Ingresses without a class
+	defaultClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+
+	// legacyClassAnnotation is the pre-IngressClass way of selecting a controller
+	legacyClassAnnotation = "kubernetes.io/ingress.class"
+)
+
+// ClassResolution describes which controller class an Ingress resolved to and why
+type ClassResolution struct {
+	Class      string // IngressClass name the Ingress resolved to
+	Controller string // spec.controller of that class, empty when unknown
+	Reason     string
+	Nginx      bool
+}
+
+// classResolver decides whether an Ingress is served by ingress-nginx based
+// on IngressClass objects. Without IngressClass objects (offline input, or
+// RBAC denies listing them) it falls back to matching class names.
+type classResolver struct {
+	controllers    map[string]bool
+	classes        map[string]string // IngressClass name -> spec.controller
+	defaultClasses []string
+	known          bool
+	prefixes       []string // annotation prefixes that identify ingress-nginx Ingresses
+}
+
+// newClassResolver creates a resolver that treats the ingress-nginx
+// controller and any extra controller names as nginx, and class-less
+// Ingresses with annotations under one of prefixes as written for it
+func newClassResolver(extraControllers []string, prefixes []string) *classResolver {
+	controllers := map[string]bool{NginxControllerName: true}
+	for _, c := range extraControllers {
+		controllers[c] = true
+	}
+	return &classResolver{
+		controllers: controllers,
+		classes:     make(map[string]string),
+		prefixes:    prefixes,
+	}
+}
+
+// addClasses registers IngressClass objects with the resolver
+func (r *classResolver) addClasses(classes []networkingv1.IngressClass) {
+	r.known = true
+	for _, class := range classes {
+		r.classes[class.Name] = class.Spec.Controller
+		if class.Annotations[defaultClassAnnotation] == "true" {
+			r.defaultClasses = append(r.defaultClasses, class.Name)
+		}
+	}
+	sort.Strings(r.defaultClasses)
+}
+
+// resolve determines the controller class of an Ingress
+func (r *classResolver) resolve(ingress networkingv1.Ingress) ClassResolution {
+	if ingress.Spec.IngressClassName != nil && *ingress.Spec.IngressClassName != "" {
+		return r.resolveClass(*ingress.Spec.IngressClassName, "spec.ingressClassName")
+	}
+
+	if class, exists := ingress.Annotations[legacyClassAnnotation]; exists && class != "" {
+		return r.resolveClass(class, legacyClassAnnotation+" annotation")
+	}
+
+	// Class-less Ingresses are admitted by the default IngressClass
+	for _, class := range r.defaultClasses {
+		controller := r.classes[class]
+		if r.controllers[controller] {
+			return ClassResolution{
+				Class:      class,
+				Controller: controller,
+				Reason:     fmt.Sprintf("no class set; default IngressClass %s has controller %s", class, controller),
+				Nginx:      true,
+			}
+		}
+	}
+	if len(r.defaultClasses) > 0 {
+		class := r.defaultClasses[0]
+		return ClassResolution{
+			Class:      class,
+			Controller: r.classes[class],
+			Reason:     fmt.Sprintf("no class set; default IngressClass %s has controller %s", class, r.classes[class]),
+		}
+	}
+
+	// Last resort: ingress-nginx annotations imply the Ingress was written for it
+	for _, prefix := range r.prefixes {
+		for key := range ingress.Annotations {
+			if strings.HasPrefix(key, prefix+"/") {
+				return ClassResolution{
+					Reason: fmt.Sprintf("no class set; has %s annotations", prefix),
+					Nginx:  true,
+				}
+			}
+		}
+	}
+
+	return ClassResolution{Reason: "no class set and no default IngressClass"}
+}
+
+// resolveClass resolves a class name taken from field
+func (r *classResolver) resolveClass(class, field string) ClassResolution {
+	if controller, exists := r.classes[class]; exists {
+		return ClassResolution{
+			Class:      class,
+			Controller: controller,
+			Reason:     fmt.Sprintf("%s %s; IngressClass has controller %s", field, class, controller),
+			Nginx:      r.controllers[controller],
+		}
+	}
+
+	nginx := strings.Contains(class, "nginx")
+	reason := fmt.Sprintf("%s %s", field, class)
+	if r.known {
+		reason += "; IngressClass not found"
+	} else {
+		reason += "; IngressClass objects unavailable"
+	}
+	if nginx {
+		reason += ", class name contains \"nginx\""
+	}
+
+	return ClassResolution{
+		Class:  class,
+		Reason: reason,
+		Nginx:  nginx,
+	}
+}
+
+// listIngressClasses lists all IngressClass objects in the cluster
+func (s *Scanner) listIngressClasses(ctx context.Context) ([]networkingv1.IngressClass, error) {
+	var classes []networkingv1.IngressClass
+
+	p := s.newPager(func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return s.client.Clientset.NetworkingV1().IngressClasses().List(ctx, opts)
+	})
+	err := p.EachListItem(ctx, metav1.ListOptions{}, func(obj runtime.Object) error {
+		classes = append(classes, *obj.(*networkingv1.IngressClass))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingress classes: %w", err)
+	}
+
+	return classes, nil
+}
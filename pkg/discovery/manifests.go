@@ -0,0 +1,219 @@
+package discovery
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// manifestExtensions are the file extensions picked up when walking a directory
+var manifestExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
+}
+
+// removedIngressGroups are the API groups whose v1beta1 Ingresses were
+// removed in Kubernetes 1.22
+var removedIngressGroups = map[string]bool{
+	"extensions":           true,
+	networkingv1.GroupName: true,
+}
+
+// SourcedIngress is an Ingress together with where it was loaded from.
+// Origin is nil for Ingresses read from the API server.
+type SourcedIngress struct {
+	Ingress networkingv1.Ingress
+	Origin  *models.ResourceOrigin
+}
+
+// ManifestLoader returns the Ingresses of an offline input
+type ManifestLoader func() ([]SourcedIngress, error)
+
+// ManifestFileLoader returns a loader that reads Ingresses from the given
+// files and directories. Directories are walked recursively and "-" reads stdin.
+func ManifestFileLoader(paths []string) ManifestLoader {
+	return func() ([]SourcedIngress, error) {
+		return LoadManifests(paths)
+	}
+}
+
+// LoadManifests reads Ingress resources from YAML/JSON files and directories.
+// Files that fail to decode are an error when named explicitly; files found
+// by walking a directory are skipped with a warning, since GitOps trees also
+// hold Helm templates and other files that are not plain manifests.
+func LoadManifests(paths []string) ([]SourcedIngress, error) {
+	var ingresses []SourcedIngress
+
+	for _, path := range paths {
+		if path == "-" {
+			items, err := DecodeManifests(os.Stdin, &models.ResourceOrigin{File: "-"})
+			if err != nil {
+				return nil, fmt.Errorf("failed to read manifests from stdin: %w", err)
+			}
+			ingresses = append(ingresses, items...)
+			continue
+		}
+
+		files, walked, err := manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			items, err := decodeManifestFile(file)
+			if err != nil && walked {
+				fmt.Printf("⚠️  Warning: %v; the file was skipped\n", err)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			ingresses = append(ingresses, items...)
+		}
+	}
+
+	return ingresses, nil
+}
+
+// manifestFiles expands a path into the manifest files it refers to,
+// reporting whether they were found by walking a directory
+func manifestFiles(path string) ([]string, bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read manifest path %s: %w", path, err)
+	}
+
+	// Explicitly named files are read regardless of extension
+	if !info.IsDir() {
+		return []string{path}, false, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Skip hidden directories such as .git
+			if p != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if manifestExtensions[strings.ToLower(filepath.Ext(p))] {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to walk manifest directory %s: %w", path, err)
+	}
+
+	return files, true, nil
+}
+
+// decodeManifestFile decodes all Ingresses in a single manifest file
+func decodeManifestFile(path string) ([]SourcedIngress, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open manifest %s: %w", path, err)
+	}
+	defer f.Close()
+
+	items, err := DecodeManifests(f, &models.ResourceOrigin{File: path})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifest %s: %w", path, err)
+	}
+
+	return items, nil
+}
+
+// DecodeManifests decodes Ingress resources from a YAML or JSON stream.
+// Multi-document streams and `kind: List` objects are supported; any other
+// kinds are ignored. Each returned Ingress is tagged with a copy of origin.
+func DecodeManifests(r io.Reader, origin *models.ResourceOrigin) ([]SourcedIngress, error) {
+	var ingresses []SourcedIngress
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bufio.NewReader(r), 4096)
+	for {
+		var obj map[string]interface{}
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if obj == nil {
+			continue // empty document
+		}
+
+		items, err := collectIngresses(&unstructured.Unstructured{Object: obj}, origin)
+		if err != nil {
+			return nil, err
+		}
+		ingresses = append(ingresses, items...)
+	}
+
+	return ingresses, nil
+}
+
+// collectIngresses extracts Ingresses from an object, descending into lists
+func collectIngresses(obj *unstructured.Unstructured, origin *models.ResourceOrigin) ([]SourcedIngress, error) {
+	if obj.IsList() {
+		var ingresses []SourcedIngress
+		err := obj.EachListItem(func(item runtime.Object) error {
+			items, err := collectIngresses(item.(*unstructured.Unstructured), origin)
+			if err != nil {
+				return err
+			}
+			ingresses = append(ingresses, items...)
+			return nil
+		})
+		return ingresses, err
+	}
+
+	gvk := obj.GroupVersionKind()
+	if gvk.Kind == "Ingress" && removedIngressGroups[gvk.Group] && gvk.Version == "v1beta1" {
+		fmt.Printf("⚠️  Warning: Ingress %s in %s uses %s, which Kubernetes no longer serves; "+
+			"it was skipped, convert it to networking.k8s.io/v1 to analyze it\n",
+			obj.GetName(), describeOrigin(origin), obj.GetAPIVersion())
+		return nil, nil
+	}
+	if gvk.Group != networkingv1.GroupName || gvk.Version != "v1" || gvk.Kind != "Ingress" {
+		return nil, nil
+	}
+
+	var ingress networkingv1.Ingress
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &ingress); err != nil {
+		return nil, fmt.Errorf("invalid Ingress %s: %w", obj.GetName(), err)
+	}
+
+	originCopy := *origin
+	return []SourcedIngress{{Ingress: ingress, Origin: &originCopy}}, nil
+}
+
+// describeOrigin names the file, template or overlay a manifest came from
+func describeOrigin(origin *models.ResourceOrigin) string {
+	switch {
+	case origin.File != "":
+		return origin.File
+	case origin.Template != "":
+		return fmt.Sprintf("template %s of chart %s", origin.Template, origin.Chart)
+	case origin.Overlay != "":
+		return fmt.Sprintf("overlay %s", origin.Overlay)
+	}
+	return "manifests"
+}
@@ -0,0 +1,121 @@
+package discovery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+func TestLoadManifestsFromTestdata(t *testing.T) {
+	ingresses, err := LoadManifests([]string{"../../testdata"})
+	if err != nil {
+		t.Fatalf("LoadManifests() error = %v", err)
+	}
+
+	// sample-apps.yaml only contains Deployments, Services and ConfigMaps
+	if len(ingresses) != 11 {
+		t.Errorf("LoadManifests() returned %d ingresses, want 11", len(ingresses))
+	}
+
+	for _, item := range ingresses {
+		if item.Origin == nil || !strings.HasPrefix(item.Origin.File, "../../testdata/") {
+			t.Errorf("Ingress %s has unexpected origin %+v", item.Ingress.Name, item.Origin)
+		}
+	}
+}
+
+func TestLoadManifestsSkipsUndecodableFilesInDirectories(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"app/ingress.yaml":        "apiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata:\n  name: web\n",
+		"chart/templates/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ingresses, err := LoadManifests([]string{dir})
+	if err != nil {
+		t.Fatalf("LoadManifests() error = %v", err)
+	}
+	if len(ingresses) != 1 || ingresses[0].Ingress.Name != "web" {
+		t.Errorf("LoadManifests() = %+v, want the web Ingress", ingresses)
+	}
+
+	template := filepath.Join(dir, "chart/templates/cm.yaml")
+	if _, err := LoadManifests([]string{template}); err == nil {
+		t.Errorf("LoadManifests(%s) error = nil, want a decode error for a file named explicitly", template)
+	}
+}
+
+func TestDecodeManifests(t *testing.T) {
+	manifest := `
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: networking.k8s.io/v1
+  kind: Ingress
+  metadata:
+    name: from-list
+    annotations:
+      nginx.ingress.kubernetes.io/ssl-redirect: "true"
+  spec:
+    rules:
+    - host: list.example.com
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: ignored
+---
+{"apiVersion": "networking.k8s.io/v1", "kind": "Ingress", "metadata": {"name": "from-json", "namespace": "web"}, "spec": {"ingressClassName": "traefik"}}
+---
+apiVersion: extensions/v1beta1
+kind: Ingress
+metadata:
+  name: removed-api-version
+`
+
+	items, err := DecodeManifests(strings.NewReader(manifest), &models.ResourceOrigin{File: "inline.yaml"})
+	if err != nil {
+		t.Fatalf("DecodeManifests() error = %v", err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("DecodeManifests() returned %d ingresses, want 2", len(items))
+	}
+	if items[0].Ingress.Name != "from-list" || items[1].Ingress.Name != "from-json" {
+		t.Errorf("unexpected ingresses %s, %s", items[0].Ingress.Name, items[1].Ingress.Name)
+	}
+
+	scanner := NewOfflineScanner(func() ([]SourcedIngress, error) { return items, nil }, "inline", "")
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	if result.TotalIngresses != 2 {
+		t.Errorf("TotalIngresses = %d, want 2", result.TotalIngresses)
+	}
+	if len(result.NginxIngresses) != 1 {
+		t.Fatalf("found %d nginx ingresses, want 1", len(result.NginxIngresses))
+	}
+
+	resource := result.NginxIngresses[0]
+	if resource.Namespace != "default" {
+		t.Errorf("Namespace = %q, want default for manifests without a namespace", resource.Namespace)
+	}
+	if resource.Origin == nil || resource.Origin.File != "inline.yaml" {
+		t.Errorf("Origin = %+v, want inline.yaml", resource.Origin)
+	}
+}
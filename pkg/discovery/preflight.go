@@ -0,0 +1,208 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Permission is an API permission the analyzer uses and the feature that needs it
+type Permission struct {
+	Group      string
+	Resource   string
+	Verb       string
+	Namespaced bool
+	Feature    string
+	Required   bool   // scans cannot run without it
+	Exposure   string // sensitive data the permission grants access to, if any
+}
+
+// FeatureWatch is the feature of the permissions only scan --watch needs
+const FeatureWatch = "Watch mode"
+
+// FeatureCertificates is the feature of the permissions only
+// --inspect-certificates needs
+const FeatureCertificates = "TLS certificate inspection (--inspect-certificates)"
+
+// Permissions lists every API permission the analyzer may use
+var Permissions = []Permission{
+	{Group: "networking.k8s.io", Resource: "ingresses", Verb: "list", Namespaced: true, Feature: "Ingress discovery", Required: true},
+	{Group: "networking.k8s.io", Resource: "ingresses", Verb: "watch", Namespaced: true, Feature: FeatureWatch},
+	{Group: "", Resource: "namespaces", Verb: "list", Feature: "Per-namespace fallback and --namespace-selector"},
+	{Group: "networking.k8s.io", Resource: "ingressclasses", Verb: "list", Feature: "IngressClass resolution"},
+	{Group: "networking.k8s.io", Resource: "ingressclasses", Verb: "watch", Feature: FeatureWatch},
+	{Group: "apps", Resource: "deployments", Verb: "list", Namespaced: true, Feature: "Controller inventory"},
+	{Group: "apps", Resource: "daemonsets", Verb: "list", Namespaced: true, Feature: "Controller inventory"},
+	{Group: "", Resource: "configmaps", Verb: "get", Namespaced: true, Feature: "Global controller settings and TCP/UDP exposure"},
+	{Group: "", Resource: "configmaps", Verb: "list", Namespaced: true, Feature: FeatureWatch},
+	{Group: "", Resource: "configmaps", Verb: "watch", Namespaced: true, Feature: FeatureWatch},
+	{Group: "", Resource: "services", Verb: "list", Namespaced: true, Feature: "Controller Service type"},
+	{Group: "", Resource: "services", Verb: "get", Namespaced: true, Feature: "Backend validation and TCP/UDP exposure"},
+	{Group: "discovery.k8s.io", Resource: "endpointslices", Verb: "list", Namespaced: true, Feature: "Backend endpoint readiness"},
+	{Group: "", Resource: "secrets", Verb: "get", Namespaced: true, Feature: FeatureCertificates,
+		Exposure: "the private keys (tls.key) of every Secret, since Kubernetes cannot grant read access to certificates alone"},
+}
+
+// PermissionCheck is the result of checking a permission with a SelfSubjectAccessReview
+type PermissionCheck struct {
+	Permission
+	Namespace string // empty for all namespaces or cluster-scoped resources
+	Allowed   bool
+	Reason    string
+}
+
+// String returns the permission as "verb resource.group"
+func (p Permission) String() string {
+	if p.Group == "" {
+		return p.Verb + " " + p.Resource
+	}
+	return p.Verb + " " + p.Resource + "." + p.Group
+}
+
+// CheckPermissions checks every permission in Permissions for the current
+// user, in namespace or across all namespaces when namespace is empty
+func CheckPermissions(ctx context.Context, client *Client, namespace string) ([]PermissionCheck, error) {
+	var checks []PermissionCheck
+	for _, permission := range Permissions {
+		check := PermissionCheck{Permission: permission}
+		if permission.Namespaced {
+			check.Namespace = namespace
+		}
+
+		review, err := client.Clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: check.Namespace,
+					Verb:      permission.Verb,
+					Group:     permission.Group,
+					Resource:  permission.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check permission to %s: %w", permission, err)
+		}
+
+		check.Allowed = review.Status.Allowed
+		check.Reason = review.Status.Reason
+		if review.Status.EvaluationError != "" && check.Reason == "" {
+			check.Reason = review.Status.EvaluationError
+		}
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// IngressListableNamespaces uses a SelfSubjectRulesReview per namespace to
+// find where Ingresses can be listed when listing them across all
+// namespaces is denied. These are the namespaces a scan falls back to.
+func IngressListableNamespaces(ctx context.Context, client *Client) (allowed []string, total int, err error) {
+	namespaces, err := NewScanner(client, "").listNamespaces(ctx, "")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	for _, ns := range namespaces {
+		review, err := client.Clientset.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, &authorizationv1.SelfSubjectRulesReview{
+			Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: ns},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to review rules in namespace %s: %w", ns, err)
+		}
+		if rulesAllow(review.Status.ResourceRules, "networking.k8s.io", "ingresses", "list") {
+			allowed = append(allowed, ns)
+		}
+	}
+
+	return allowed, len(namespaces), nil
+}
+
+// rulesAllow reports whether any resource rule grants verb on group/resource
+func rulesAllow(rules []authorizationv1.ResourceRule, group, resource, verb string) bool {
+	matches := func(values []string, value string) bool {
+		return slices.Contains(values, "*") || slices.Contains(values, value)
+	}
+	for _, rule := range rules {
+		// Rules limited to resourceNames do not allow listing
+		if len(rule.ResourceNames) > 0 {
+			continue
+		}
+		if matches(rule.APIGroups, group) && matches(rule.Resources, resource) && matches(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// DegradedFeatures returns the features that will not work because a
+// permission they need was denied, sorted
+func DegradedFeatures(checks []PermissionCheck) []string {
+	var features []string
+	for _, check := range checks {
+		if !check.Allowed && !slices.Contains(features, check.Feature) {
+			features = append(features, check.Feature)
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// ClusterRoleYAML returns a minimal ClusterRole granting every permission
+// the analyzer uses
+func ClusterRoleYAML(name string) string {
+	type ruleKey struct{ group, resource string }
+	verbs := make(map[ruleKey][]string)
+	exposures := make(map[ruleKey][]string)
+	var keys []ruleKey
+	for _, permission := range Permissions {
+		key := ruleKey{permission.Group, permission.Resource}
+		if _, exists := verbs[key]; !exists {
+			keys = append(keys, key)
+		}
+		if permission.Exposure != "" {
+			exposures[key] = append(exposures[key], fmt.Sprintf("%s exposes %s; only %s needs it, remove this rule otherwise",
+				permission, permission.Exposure, permission.Feature))
+		}
+		if !slices.Contains(verbs[key], permission.Verb) {
+			verbs[key] = append(verbs[key], permission.Verb)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].group != keys[j].group {
+			return keys[i].group < keys[j].group
+		}
+		return keys[i].resource < keys[j].resource
+	})
+
+	var content strings.Builder
+	content.WriteString("apiVersion: rbac.authorization.k8s.io/v1\n")
+	content.WriteString("kind: ClusterRole\n")
+	content.WriteString("metadata:\n")
+	content.WriteString(fmt.Sprintf("  name: %s\n", name))
+	content.WriteString("rules:\n")
+	for _, key := range keys {
+		sort.Strings(verbs[key])
+		for _, exposure := range exposures[key] {
+			content.WriteString(fmt.Sprintf("# %s\n", exposure))
+		}
+		content.WriteString(fmt.Sprintf("- apiGroups: [%q]\n", key.group))
+		content.WriteString(fmt.Sprintf("  resources: [%q]\n", key.resource))
+		content.WriteString(fmt.Sprintf("  verbs: [%s]\n", quoteJoin(verbs[key])))
+	}
+	return content.String()
+}
+
+// quoteJoin quotes and comma-separates values for a YAML flow sequence
+func quoteJoin(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
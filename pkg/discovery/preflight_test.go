@@ -0,0 +1,67 @@
+package discovery
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCheckPermissions(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		if attributes.Namespace != "shop" && attributes.Resource != "namespaces" && attributes.Resource != "ingressclasses" {
+			t.Errorf("namespaced permission %s/%s checked in namespace %q, want shop", attributes.Verb, attributes.Resource, attributes.Namespace)
+		}
+		review.Status.Allowed = attributes.Resource != "secrets" && attributes.Resource != "endpointslices"
+		return true, review, nil
+	})
+
+	checks, err := CheckPermissions(context.Background(), &Client{Clientset: clientset}, "shop")
+	if err != nil {
+		t.Fatalf("CheckPermissions() error = %v", err)
+	}
+	if len(checks) != len(Permissions) {
+		t.Fatalf("got %d checks, want %d", len(checks), len(Permissions))
+	}
+
+	want := []string{"Backend endpoint readiness", FeatureCertificates}
+	if degraded := DegradedFeatures(checks); !reflect.DeepEqual(degraded, want) {
+		t.Errorf("DegradedFeatures() = %v, want %v", degraded, want)
+	}
+}
+
+func TestRulesAllow(t *testing.T) {
+	rules := []authorizationv1.ResourceRule{
+		{Verbs: []string{"get"}, APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, ResourceNames: []string{"web"}},
+		{Verbs: []string{"*"}, APIGroups: []string{"networking.k8s.io"}, Resources: []string{"*"}},
+	}
+	if !rulesAllow(rules, "networking.k8s.io", "ingresses", "list") {
+		t.Error("wildcard rule should allow listing ingresses")
+	}
+	if rulesAllow(rules[:1], "networking.k8s.io", "ingresses", "list") {
+		t.Error("a rule limited to resourceNames should not allow listing")
+	}
+}
+
+func TestClusterRoleYAML(t *testing.T) {
+	yaml := ClusterRoleYAML("analyzer")
+	for _, want := range []string{
+		"kind: ClusterRole\n",
+		"  name: analyzer\n",
+		"- apiGroups: [\"networking.k8s.io\"]\n  resources: [\"ingresses\"]\n  verbs: [\"list\", \"watch\"]\n",
+		"# get secrets exposes the private keys (tls.key) of every Secret",
+		"  resources: [\"secrets\"]\n  verbs: [\"get\"]\n",
+	} {
+		if !strings.Contains(yaml, want) {
+			t.Errorf("ClusterRole YAML missing %q:\n%s", want, yaml)
+		}
+	}
+}
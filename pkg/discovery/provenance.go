@@ -0,0 +1,76 @@
+package discovery
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// Labels and annotations that identify the tools managing a resource
+const (
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+	argoCDTrackingAnnotation       = "argocd.argoproj.io/tracking-id"
+	argoCDInstanceLabel            = "argocd.argoproj.io/instance"
+	fluxKustomizationNameLabel     = "kustomize.toolkit.fluxcd.io/name"
+	fluxKustomizationNSLabel       = "kustomize.toolkit.fluxcd.io/namespace"
+	fluxHelmReleaseNameLabel       = "helm.toolkit.fluxcd.io/name"
+	fluxHelmReleaseNSLabel         = "helm.toolkit.fluxcd.io/namespace"
+	partOfLabel                    = "app.kubernetes.io/part-of"
+	managedByLabel                 = "app.kubernetes.io/managed-by"
+	instanceLabel                  = "app.kubernetes.io/instance"
+)
+
+// extractProvenance records what manages a resource from its
+// ownerReferences, labels and annotations. It returns nil when nothing does.
+func extractProvenance(meta metav1.ObjectMeta) *models.Provenance {
+	var provenance models.Provenance
+	for _, owner := range meta.OwnerReferences {
+		provenance.Owners = append(provenance.Owners, owner.Kind+"/"+owner.Name)
+	}
+
+	// Helm records the release in annotations since Helm 3.2; older charts
+	// only carry the managed-by and instance labels
+	if name := meta.Annotations[helmReleaseNameAnnotation]; name != "" {
+		provenance.HelmRelease = namespacedName(meta.Annotations[helmReleaseNamespaceAnnotation], name, meta.Namespace)
+	} else if meta.Labels[managedByLabel] == "Helm" && meta.Labels[instanceLabel] != "" {
+		provenance.HelmRelease = namespacedName(meta.Namespace, meta.Labels[instanceLabel], meta.Namespace)
+	}
+
+	// The tracking id is <application>:<group>/<kind>:<namespace>/<name>
+	if tracking := meta.Annotations[argoCDTrackingAnnotation]; tracking != "" {
+		provenance.ArgoCDApplication, _, _ = strings.Cut(tracking, ":")
+	} else {
+		provenance.ArgoCDApplication = meta.Labels[argoCDInstanceLabel]
+	}
+
+	if name := meta.Labels[fluxKustomizationNameLabel]; name != "" {
+		provenance.FluxKustomization = namespacedName(meta.Labels[fluxKustomizationNSLabel], name, meta.Namespace)
+	}
+	if name := meta.Labels[fluxHelmReleaseNameLabel]; name != "" {
+		provenance.FluxHelmRelease = namespacedName(meta.Labels[fluxHelmReleaseNSLabel], name, meta.Namespace)
+	}
+
+	provenance.PartOf = meta.Labels[partOfLabel]
+	provenance.ManagedBy = meta.Labels[managedByLabel]
+
+	if len(provenance.Owners) == 0 && provenance.HelmRelease == "" && provenance.ArgoCDApplication == "" &&
+		provenance.FluxKustomization == "" && provenance.FluxHelmRelease == "" &&
+		provenance.PartOf == "" && provenance.ManagedBy == "" {
+		return nil
+	}
+	return &provenance
+}
+
+// namespacedName joins namespace and name, defaulting the namespace
+func namespacedName(namespace, name, defaultNamespace string) string {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
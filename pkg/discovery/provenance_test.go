@@ -0,0 +1,76 @@
+package discovery
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+func TestExtractProvenance(t *testing.T) {
+	tests := []struct {
+		name string
+		meta metav1.ObjectMeta
+		want *models.Provenance
+	}{
+		{
+			name: "unmanaged",
+			meta: metav1.ObjectMeta{Namespace: "shop", Labels: map[string]string{"app": "web"}},
+		},
+		{
+			name: "Helm release deployed by Argo CD",
+			meta: metav1.ObjectMeta{
+				Namespace: "shop",
+				Annotations: map[string]string{
+					"meta.helm.sh/release-name":      "web",
+					"meta.helm.sh/release-namespace": "shop",
+					"argocd.argoproj.io/tracking-id": "shop-web:networking.k8s.io/Ingress:shop/web",
+				},
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "Helm", "app.kubernetes.io/part-of": "storefront"},
+			},
+			want: &models.Provenance{
+				HelmRelease:       "shop/web",
+				ArgoCDApplication: "shop-web",
+				PartOf:            "storefront",
+				ManagedBy:         "Helm",
+			},
+		},
+		{
+			name: "Helm labels without release annotations, and Flux",
+			meta: metav1.ObjectMeta{
+				Namespace: "payments",
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by":          "Helm",
+					"app.kubernetes.io/instance":            "api",
+					"kustomize.toolkit.fluxcd.io/name":      "apps",
+					"kustomize.toolkit.fluxcd.io/namespace": "flux-system",
+					"helm.toolkit.fluxcd.io/name":           "api",
+				},
+			},
+			want: &models.Provenance{
+				HelmRelease:       "payments/api",
+				FluxKustomization: "flux-system/apps",
+				FluxHelmRelease:   "payments/api",
+				ManagedBy:         "Helm",
+			},
+		},
+		{
+			name: "owned by an operator",
+			meta: metav1.ObjectMeta{
+				Namespace:       "search",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Elasticsearch", Name: "logs"}},
+			},
+			want: &models.Provenance{Owners: []string{"Elasticsearch/logs"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractProvenance(tt.meta); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractProvenance() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// apiCallTimeout bounds each API request, including each page of a List
+const apiCallTimeout = 30 * time.Second
+
+// apiBackoff is the backoff between attempts of an API call that failed
+// with a transient error; Steps is the maximum number of attempts
+var apiBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+}
+
+// callAPI calls fn with a per-call timeout, retrying transient errors with
+// backoff until the attempts run out or ctx is done. Permanent errors such
+// as Forbidden or NotFound are returned right away.
+func callAPI[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) (T, error) {
+	backoff := apiBackoff
+	for attempt := 1; ; attempt++ {
+		callCtx, cancel := context.WithTimeout(ctx, apiCallTimeout)
+		result, err := fn(callCtx)
+		cancel()
+		if err == nil || !isTransient(err) || ctx.Err() != nil {
+			return result, err
+		}
+
+		if backoff.Steps <= 1 {
+			return result, fmt.Errorf("%w (gave up after %d attempts)", err, attempt)
+		}
+		delay := backoff.Step()
+		if seconds, ok := apierrors.SuggestsClientDelay(err); ok && time.Duration(seconds)*time.Second > delay {
+			delay = time.Duration(seconds) * time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// isTransient reports whether err is worth retrying: throttling, server
+// overload, timeouts and dropped connections
+func isTransient(err error) bool {
+	switch {
+	case apierrors.IsTooManyRequests(err),
+		apierrors.IsServerTimeout(err),
+		apierrors.IsTimeout(err),
+		apierrors.IsServiceUnavailable(err),
+		apierrors.IsInternalError(err),
+		apierrors.IsUnexpectedServerError(err):
+		return true
+	case errors.Is(err, context.DeadlineExceeded):
+		// The per-call timeout expired; callAPI checks the parent context
+		return true
+	}
+	return utilnet.IsTimeout(err) ||
+		utilnet.IsConnectionReset(err) ||
+		utilnet.IsConnectionRefused(err) ||
+		utilnet.IsProbableEOF(err) ||
+		utilnet.IsHTTP2ConnectionLost(err)
+}
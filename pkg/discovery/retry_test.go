@@ -0,0 +1,147 @@
+package discovery
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// fastBackoff shortens the retry backoff for the duration of a test
+func fastBackoff(t *testing.T) {
+	saved := apiBackoff
+	apiBackoff = wait.Backoff{Steps: saved.Steps, Duration: time.Millisecond, Factor: 1}
+	t.Cleanup(func() { apiBackoff = saved })
+}
+
+func TestScanRetriesTransientErrors(t *testing.T) {
+	fastBackoff(t)
+	clientset := fake.NewSimpleClientset(nginxIngress("shop", "web"))
+	var calls int
+	clientset.PrependReactor("list", "ingresses", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		if calls <= 2 {
+			return true, nil, apierrors.NewServiceUnavailable("etcd leader changed")
+		}
+		return false, nil, nil
+	})
+
+	result, err := NewScanner(&Client{Clientset: clientset}, "").ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+	if calls != 3 || result.TotalIngresses != 1 || len(result.ScanErrors) != 0 {
+		t.Errorf("got %d calls, %d ingresses and scan errors %+v; want 3, 1 and none", calls, result.TotalIngresses, result.ScanErrors)
+	}
+}
+
+func TestScanRecordsPersistentErrors(t *testing.T) {
+	fastBackoff(t)
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "flaky"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "locked"}},
+		nginxIngress("shop", "web"),
+	)
+	ingresses := schema.GroupResource{Group: "networking.k8s.io", Resource: "ingresses"}
+	calls := make(map[string]int)
+	clientset.PrependReactor("list", "ingresses", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		namespace := action.GetNamespace()
+		calls[namespace]++
+		switch namespace {
+		case metav1.NamespaceAll, "locked":
+			return true, nil, apierrors.NewForbidden(ingresses, "", nil)
+		case "flaky":
+			return true, nil, apierrors.NewServiceUnavailable("apiserver overloaded")
+		}
+		return false, nil, nil
+	})
+	clientset.PrependReactor("list", "ingressclasses", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "networking.k8s.io", Resource: "ingressclasses"}, "", nil)
+	})
+
+	scanner := NewScanner(&Client{Clientset: clientset}, "")
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	if calls["locked"] != 1 || calls["flaky"] != apiBackoff.Steps {
+		t.Errorf("calls = %v, want Forbidden tried once and transient errors %d times", calls, apiBackoff.Steps)
+	}
+	if result.TotalIngresses != 1 {
+		t.Errorf("TotalIngresses = %d, want 1", result.TotalIngresses)
+	}
+
+	want := []struct {
+		stage     models.ScanStage
+		namespace string
+		error     string
+	}{
+		{models.StageIngressClasses, "", "forbidden"},
+		{models.StageIngresses, "flaky", "gave up after 4 attempts"},
+		{models.StageIngresses, "locked", "forbidden"},
+	}
+	if len(result.ScanErrors) != len(want) {
+		t.Fatalf("ScanErrors = %+v, want %d errors", result.ScanErrors, len(want))
+	}
+	for i, w := range want {
+		got := result.ScanErrors[i]
+		if got.Stage != w.stage || got.Namespace != w.namespace || !strings.Contains(got.Error, w.error) || got.Impact == "" {
+			t.Errorf("ScanErrors[%d] = %+v, want %s in %q containing %q", i, got, w.stage, w.namespace, w.error)
+		}
+	}
+}
+
+func TestScanRecordsSecretAndServiceErrorsOncePerNamespace(t *testing.T) {
+	fastBackoff(t)
+	ingress := nginxIngress("shop", "web")
+	ingress.Spec.TLS = []networkingv1.IngressTLS{{SecretName: "web-tls"}, {SecretName: "api-tls"}}
+	ingress.Spec.Rules = []networkingv1.IngressRule{{
+		IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+			Paths: []networkingv1.HTTPIngressPath{
+				{Path: "/", Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80}}}},
+				{Path: "/api", Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api", Port: networkingv1.ServiceBackendPort{Number: 80}}}},
+			},
+		}},
+	}}
+	clientset := fake.NewSimpleClientset(ingress)
+	clientset.PrependReactor("get", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", nil)
+	})
+	clientset.PrependReactor("get", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewServiceUnavailable("apiserver overloaded")
+	})
+
+	scanner := NewScanner(&Client{Clientset: clientset}, "")
+	scanner.SetOptions(ScanOptions{InspectCertificates: true})
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	want := []models.ScanStage{models.StageBackends, models.StageTLSSecrets}
+	if len(result.ScanErrors) != len(want) {
+		t.Fatalf("ScanErrors = %+v, want one %v error each", result.ScanErrors, want)
+	}
+	for i, stage := range want {
+		if got := result.ScanErrors[i]; got.Stage != stage || got.Namespace != "shop" || got.Impact == "" {
+			t.Errorf("ScanErrors[%d] = %+v, want %s in shop", i, got, stage)
+		}
+	}
+	if tls := result.NginxIngresses[0].TLS; len(tls[1].Issues) == 0 {
+		t.Errorf("TLS = %+v, want the per-Secret issue kept", tls)
+	}
+}
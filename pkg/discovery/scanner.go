@@ -3,19 +3,71 @@ package discovery
 import (
 	"context"
 	"fmt"
-	"strings"
+	"slices"
+	"sort"
+	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/pager"
 
 	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/rules"
+)
+
+const (
+	// listPageSize is the number of objects requested per List call
+	listPageSize = 500
+
+	// namespaceListConcurrency bounds parallel List calls in per-namespace fallback mode
+	namespaceListConcurrency = 10
 )
 
 // Scanner handles discovery of Ingress resources
 type Scanner struct {
 	client    *Client
 	namespace string
+	loader    ManifestLoader // set for offline scans instead of client
+	source    string
+
+	options ScanOptions
+
+	mu         sync.Mutex
+	scanErrors []models.ScanError
+}
+
+// ScanOptions configures optional scanner behavior
+type ScanOptions struct {
+	// ControllerClasses are additional IngressClass spec.controller values
+	// treated as ingress-nginx, next to NginxControllerName
+	ControllerClasses []string
+
+	// AnnotationPrefix overrides the annotation prefix auto-detected from
+	// the controllers' --annotation-prefix argument
+	AnnotationPrefix string
+
+	// IncludeNamespaces limits the scan to namespaces matching any of these
+	// glob patterns; ExcludeNamespaces skips namespaces matching any of them
+	IncludeNamespaces []string
+	ExcludeNamespaces []string
+
+	// NamespaceSelector limits the scan to namespaces matching this label selector
+	NamespaceSelector string
+
+	// Selector limits the scan to Ingresses matching this label selector
+	Selector string
+
+	// Names limits the scan to Ingresses whose name matches any of these glob patterns
+	Names []string
+
+	// InspectCertificates reads the TLS Secrets of every Ingress to check
+	// their certificates. Off by default: reading a Secret also transfers
+	// its private key.
+	InspectCertificates bool
 }
 
 // NewScanner creates a new scanner instance
@@ -26,38 +78,151 @@ func NewScanner(client *Client, namespace string) *Scanner {
 	}
 }
 
+// NewOfflineScanner creates a scanner that reads Ingresses from loader
+// instead of the API server. source describes the input in reports.
+func NewOfflineScanner(loader ManifestLoader, source, namespace string) *Scanner {
+	return &Scanner{
+		namespace: namespace,
+		loader:    loader,
+		source:    source,
+	}
+}
+
+// SetOptions configures optional scanner behavior
+func (s *Scanner) SetOptions(options ScanOptions) {
+	s.options = options
+}
+
 // ScanCluster scans the cluster for ingress-nginx resources
 func (s *Scanner) ScanCluster(ctx context.Context) (*models.ScanResult, error) {
-	fmt.Println("🔍 Scanning cluster for Ingress resources...")
+	if s.loader != nil {
+		fmt.Printf("🔍 Reading Ingress resources from %s...\n", s.source)
+	} else {
+		fmt.Println("🔍 Scanning cluster for Ingress resources...")
+	}
+
+	s.scanErrors = nil
 
 	// Get all Ingress resources
-	ingresses, err := s.listIngresses(ctx)
+	ingresses, err := s.loadIngresses(ctx)
+	if err != nil && s.loader != nil {
+		return nil, fmt.Errorf("failed to read ingresses from %s: %w", s.source, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to list ingresses: %w", err)
 	}
 
 	fmt.Printf("📊 Found %d total Ingress resources\n", len(ingresses))
 
-	// Filter for nginx ingresses
-	nginxIngresses := s.filterNginxIngresses(ingresses)
-	fmt.Printf("🎯 Found %d ingress-nginx resources\n", len(nginxIngresses))
-
-	// Convert to our model
-	ingressResources := s.convertToModel(nginxIngresses)
-
 	result := &models.ScanResult{
-		ClusterVersion: s.client.ClusterVersion,
 		TotalIngresses: len(ingresses),
-		NginxIngresses: ingressResources,
 		ScanTime:       time.Now(),
+		Source:         s.source,
+		Filters:        s.filters(),
+	}
+	if s.client != nil {
+		result.ClusterVersion = s.client.ClusterVersion
+
+		controllers, configs, err := s.findControllers(ctx)
+		if err != nil {
+			s.recordError(models.StageControllers, "", err, "controller inventory, global settings and annotation prefix detection were skipped")
+		} else {
+			fmt.Printf("⚙️  Found %d ingress-nginx controllers\n", len(controllers))
+		}
+		result.Controllers = controllers
+		result.ControllerConfigs = configs
+	}
+
+	prefixes := s.annotationPrefixes(result.Controllers)
+	resolver := s.newClassResolver(ctx, prefixes)
+
+	// Filter for nginx ingresses and convert to our model
+	var ingressResources []models.IngressResource
+	for _, item := range ingresses {
+		resolution := resolver.resolve(item.Ingress)
+		if !resolution.Nginx {
+			continue
+		}
+		ingressResources = append(ingressResources, s.newIngressResource(item, resolution))
+	}
+	fmt.Printf("🎯 Found %d ingress-nginx resources\n", len(ingressResources))
+
+	attributeControllers(ingressResources, result.Controllers)
+	if s.client != nil {
+		s.resolveBackends(ctx, ingressResources)
+		if s.options.InspectCertificates {
+			s.inspectCertificates(ctx, ingressResources)
+		}
 	}
+	assignAnnotationPrefixes(ingressResources, result.Controllers, s.options.AnnotationPrefix, prefixes)
+	result.NginxIngresses = ingressResources
+	result.ScanErrors = s.sortedScanErrors()
 
 	return result, nil
 }
 
-// listIngresses gets all Ingress resources from the cluster
+// loadIngresses returns the Ingresses to analyze from the cluster or the offline loader
+func (s *Scanner) loadIngresses(ctx context.Context) ([]SourcedIngress, error) {
+	if s.loader != nil {
+		return s.loadOfflineIngresses(ctx)
+	}
+
+	ingresses, err := s.listIngresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sourced := make([]SourcedIngress, 0, len(ingresses))
+	for _, ingress := range ingresses {
+		sourced = append(sourced, SourcedIngress{Ingress: ingress})
+	}
+	return sourced, nil
+}
+
+// loadOfflineIngresses reads Ingresses from the offline loader, defaulting
+// the namespace the way `kubectl apply` would and honoring --namespace
+func (s *Scanner) loadOfflineIngresses(ctx context.Context) ([]SourcedIngress, error) {
+	filter, err := s.newIngressFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.loader()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultNamespace := s.namespace
+	if defaultNamespace == "" {
+		defaultNamespace = metav1.NamespaceDefault
+	}
+
+	var ingresses []SourcedIngress
+	for _, item := range items {
+		if item.Ingress.Namespace == "" {
+			item.Ingress.Namespace = defaultNamespace
+		}
+		if s.namespace != "" && item.Ingress.Namespace != s.namespace {
+			continue
+		}
+		if !filter.matchesIngress(item.Ingress) {
+			continue
+		}
+		ingresses = append(ingresses, item)
+	}
+
+	return ingresses, nil
+}
+
+// listIngresses gets all Ingress resources from the cluster that pass the
+// scan filters. It prefers a single paginated
+// cluster-wide List and falls back to per-namespace listing when RBAC
+// forbids listing Ingresses across all namespaces.
 func (s *Scanner) listIngresses(ctx context.Context) ([]networkingv1.Ingress, error) {
-	var allIngresses []networkingv1.Ingress
+	filter, err := s.newIngressFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	if s.namespace != "" {
 		// Scan specific namespace
@@ -65,91 +230,231 @@ func (s *Scanner) listIngresses(ctx context.Context) ([]networkingv1.Ingress, er
 		if err != nil {
 			return nil, err
 		}
-		allIngresses = ingresses
-	} else {
-		// Scan all namespaces
-		namespaces, err := s.client.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-		if err != nil {
-			return nil, fmt.Errorf("failed to list namespaces: %w", err)
-		}
+		return filter.filterIngresses(ingresses), nil
+	}
+
+	ingresses, err := s.listIngressesInNamespace(ctx, metav1.NamespaceAll)
+	if err == nil {
+		return filter.filterIngresses(ingresses), nil
+	}
+	if !apierrors.IsForbidden(err) {
+		return nil, err
+	}
+
+	fmt.Println("⚠️  Listing Ingresses across all namespaces is forbidden, falling back to per-namespace listing")
+	ingresses, err = s.listIngressesPerNamespace(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return filter.filterIngresses(ingresses), nil
+}
 
-		for _, ns := range namespaces.Items {
-			ingresses, err := s.listIngressesInNamespace(ctx, ns.Name)
+// listIngressesPerNamespace lists ingresses namespace by namespace with
+// bounded parallelism, skipping namespaces that filter excludes.
+// Namespaces that fail are recorded as scan errors and skipped.
+func (s *Scanner) listIngressesPerNamespace(ctx context.Context, filter *ingressFilter) ([]networkingv1.Ingress, error) {
+	namespaces, err := s.listNamespaces(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	namespaces = filter.filterNamespaces(namespaces)
+
+	var (
+		mu           sync.Mutex
+		wg           sync.WaitGroup
+		allIngresses []networkingv1.Ingress
+	)
+	sem := make(chan struct{}, namespaceListConcurrency)
+
+	for _, ns := range namespaces {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(ns string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			ingresses, err := s.listIngressesInNamespace(ctx, ns)
 			if err != nil {
-				fmt.Printf("⚠️  Warning: failed to list ingresses in namespace %s: %v\n", ns.Name, err)
-				continue
+				s.recordError(models.StageIngresses, ns, err, "Ingresses in this namespace are missing from the report")
+				return
 			}
+
+			mu.Lock()
+			defer mu.Unlock()
 			allIngresses = append(allIngresses, ingresses...)
-		}
+		}(ns)
 	}
+	wg.Wait()
+
+	// Keep output stable regardless of completion order
+	sort.Slice(allIngresses, func(i, j int) bool {
+		if allIngresses[i].Namespace != allIngresses[j].Namespace {
+			return allIngresses[i].Namespace < allIngresses[j].Namespace
+		}
+		return allIngresses[i].Name < allIngresses[j].Name
+	})
 
 	return allIngresses, nil
 }
 
-// listIngressesInNamespace lists ingresses in a specific namespace
+// listNamespaces returns the names of all namespaces matching
+// labelSelector (all namespaces when empty), paginated
+func (s *Scanner) listNamespaces(ctx context.Context, labelSelector string) ([]string, error) {
+	var namespaces []string
+
+	p := s.newPager(func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return s.client.Clientset.CoreV1().Namespaces().List(ctx, opts)
+	})
+	err := p.EachListItem(ctx, metav1.ListOptions{LabelSelector: labelSelector}, func(obj runtime.Object) error {
+		namespaces = append(namespaces, obj.(*corev1.Namespace).Name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return namespaces, nil
+}
+
+// listIngressesInNamespace lists ingresses in a specific namespace, or in
+// all namespaces for metav1.NamespaceAll, in chunks of listPageSize. The
+// label selector and exact-name filters are applied server-side.
 func (s *Scanner) listIngressesInNamespace(ctx context.Context, namespace string) ([]networkingv1.Ingress, error) {
-	ingressList, err := s.client.Clientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	var ingresses []networkingv1.Ingress
+
+	p := s.newPager(func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return s.client.Clientset.NetworkingV1().Ingresses(namespace).List(ctx, opts)
+	})
+	err := p.EachListItem(ctx, s.ingressListOptions(), func(obj runtime.Object) error {
+		ingresses = append(ingresses, *obj.(*networkingv1.Ingress))
+		return nil
+	})
 	if err != nil {
+		if namespace == metav1.NamespaceAll {
+			return nil, fmt.Errorf("failed to list ingresses in all namespaces: %w", err)
+		}
 		return nil, fmt.Errorf("failed to list ingresses in namespace %s: %w", namespace, err)
 	}
 
-	return ingressList.Items, nil
+	return ingresses, nil
 }
 
-// filterNginxIngresses filters ingresses that use nginx
-func (s *Scanner) filterNginxIngresses(ingresses []networkingv1.Ingress) []networkingv1.Ingress {
-	var nginxIngresses []networkingv1.Ingress
-
-	for _, ingress := range ingresses {
-		if s.isNginxIngress(ingress) {
-			nginxIngresses = append(nginxIngresses, ingress)
+// recordError prints a warning and records a failure that leaves part of
+// the scan incomplete. Only the first failure of a stage and namespace is
+// recorded. It is safe for concurrent use.
+func (s *Scanner) recordError(stage models.ScanStage, namespace string, err error, impact string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, recorded := range s.scanErrors {
+		if recorded.Stage == stage && recorded.Namespace == namespace {
+			return
 		}
 	}
 
-	return nginxIngresses
+	fmt.Printf("⚠️  Warning: %v; %s\n", err, impact)
+	s.scanErrors = append(s.scanErrors, models.ScanError{
+		Stage:     stage,
+		Namespace: namespace,
+		Error:     err.Error(),
+		Impact:    impact,
+	})
 }
 
-// isNginxIngress determines if an Ingress uses nginx
-func (s *Scanner) isNginxIngress(ingress networkingv1.Ingress) bool {
-	// Check IngressClassName
-	if ingress.Spec.IngressClassName != nil && *ingress.Spec.IngressClassName == "nginx" {
-		return true
-	}
+// sortedScanErrors returns the recorded scan errors by stage and namespace
+func (s *Scanner) sortedScanErrors() []models.ScanError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sort.Slice(s.scanErrors, func(i, j int) bool {
+		if s.scanErrors[i].Stage != s.scanErrors[j].Stage {
+			return s.scanErrors[i].Stage < s.scanErrors[j].Stage
+		}
+		return s.scanErrors[i].Namespace < s.scanErrors[j].Namespace
+	})
+	return s.scanErrors
+}
+
+// newPager creates a List pager using Limit/Continue chunking. Each page
+// is requested with callAPI, so pages time out and are retried on their own.
+func (s *Scanner) newPager(fn pager.ListPageFunc) *pager.ListPager {
+	p := pager.New(func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return callAPI(ctx, func(ctx context.Context) (runtime.Object, error) {
+			return fn(ctx, opts)
+		})
+	})
+	p.PageSize = listPageSize
+	return p
+}
 
-	// Check legacy annotation
-	if class, exists := ingress.Annotations["kubernetes.io/ingress.class"]; exists && class == "nginx" {
-		return true
+// annotationPrefixes returns the annotation prefixes in use: the override
+// or the default, plus any custom --annotation-prefix of the controllers
+func (s *Scanner) annotationPrefixes(controllers []models.IngressController) []string {
+	prefixes := []string{rules.DefaultAnnotationPrefix}
+	if s.options.AnnotationPrefix != "" {
+		prefixes = []string{s.options.AnnotationPrefix}
 	}
 
-	// Check for any nginx-specific annotations
-	for key := range ingress.Annotations {
-		if strings.HasPrefix(key, "nginx.ingress.kubernetes.io/") {
-			return true
+	for _, controller := range controllers {
+		if !slices.Contains(prefixes, controller.AnnotationPrefix) {
+			prefixes = append(prefixes, controller.AnnotationPrefix)
 		}
 	}
+	return prefixes
+}
+
+// newClassResolver builds the resolver that decides which Ingresses belong
+// to ingress-nginx. IngressClass objects are read from the cluster when
+// possible; offline scans and clusters that deny listing them fall back to
+// class-name matching.
+func (s *Scanner) newClassResolver(ctx context.Context, prefixes []string) *classResolver {
+	resolver := newClassResolver(s.options.ControllerClasses, prefixes)
+	if s.client == nil {
+		return resolver
+	}
 
-	return false
+	classes, err := s.listIngressClasses(ctx)
+	if err != nil {
+		s.recordError(models.StageIngressClasses, "", err, "IngressClasses were matched by name; custom controller classes and the default class were not resolved")
+		return resolver
+	}
+	resolver.addClasses(classes)
+
+	return resolver
 }
 
-// convertToModel converts Kubernetes Ingress to our internal model
-func (s *Scanner) convertToModel(ingresses []networkingv1.Ingress) []models.IngressResource {
-	var resources []models.IngressResource
+// newIngressResource converts an ingress-nginx Ingress and records how its class was resolved
+func (s *Scanner) newIngressResource(item SourcedIngress, resolution ClassResolution) models.IngressResource {
+	resource := s.convertIngress(item.Ingress)
+	resource.Origin = item.Origin
+	resource.ResolvedClass = resolution.Class
+	resource.Controller = resolution.Controller
+	resource.ClassResolution = resolution.Reason
+	return resource
+}
 
-	for _, ingress := range ingresses {
-		resource := models.IngressResource{
-			Name:        ingress.Name,
-			Namespace:   ingress.Namespace,
-			ClassName:   s.getIngressClass(ingress),
-			Annotations: s.copyMap(ingress.Annotations),
-			Labels:      s.copyMap(ingress.Labels),
-			Hosts:       s.extractHosts(ingress),
-			Paths:       s.extractPaths(ingress),
-			CreatedAt:   ingress.CreationTimestamp.Time,
+// convertIngress converts a single Kubernetes Ingress to our internal model
+func (s *Scanner) convertIngress(ingress networkingv1.Ingress) models.IngressResource {
+	hosts := s.extractHosts(ingress)
+	resource := models.IngressResource{
+		Name:        ingress.Name,
+		Namespace:   ingress.Namespace,
+		ClassName:   s.getIngressClass(ingress),
+		Annotations: s.copyMap(ingress.Annotations),
+		Labels:      s.copyMap(ingress.Labels),
+		Hosts:       hosts,
+		Paths:       s.extractPaths(ingress),
+		Rules:       extractRules(ingress),
+		Backends:    extractBackends(ingress),
+		TLS:         extractTLS(ingress, hosts),
+		CreatedAt:   ingress.CreationTimestamp.Time,
+		Addresses:   extractAddresses(ingress),
+		Provenance:  extractProvenance(ingress.ObjectMeta),
+	}
+	if ingress.Spec.DefaultBackend != nil {
+		if ref, ok := newBackendRef(*ingress.Spec.DefaultBackend); ok {
+			resource.DefaultBackend = &ref
 		}
-		resources = append(resources, resource)
 	}
-
-	return resources
+	return resource
 }
 
 // getIngressClass extracts the ingress class name
@@ -215,4 +520,39 @@ func (s *Scanner) extractPaths(ingress networkingv1.Ingress) []string {
 	}
 
 	return paths
+}
+
+// extractRules extracts the routing table of an Ingress in spec order,
+// keeping each path with its host, path type and backend
+func extractRules(ingress networkingv1.Ingress) []models.IngressRule {
+	var ingressRules []models.IngressRule
+	for _, rule := range ingress.Spec.Rules {
+		r := models.IngressRule{Host: rule.Host}
+		if rule.HTTP != nil {
+			for _, path := range rule.HTTP.Paths {
+				p := models.IngressPath{Path: path.Path}
+				if path.PathType != nil {
+					p.PathType = string(*path.PathType)
+				}
+				p.Backend, _ = newBackendRef(path.Backend)
+				r.Paths = append(r.Paths, p)
+			}
+		}
+		ingressRules = append(ingressRules, r)
+	}
+	return ingressRules
+}
+
+// extractAddresses extracts the load balancer IPs and hostnames an Ingress
+// reports in its status
+func extractAddresses(ingress networkingv1.Ingress) []string {
+	var addresses []string
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		for _, address := range []string{lb.IP, lb.Hostname} {
+			if address != "" && !slices.Contains(addresses, address) {
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	return addresses
 }
\ No newline at end of file
@@ -0,0 +1,722 @@
+package discovery
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/rules"
+)
+
+func nginxIngress(namespace, name string) *networkingv1.Ingress {
+	className := "nginx"
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       networkingv1.IngressSpec{IngressClassName: &className},
+	}
+}
+
+func TestListIngressesFallsBackPerNamespace(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "locked"}},
+		nginxIngress("shop", "web"),
+		nginxIngress("payments", "api"),
+	)
+	ingressResource := schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
+	clientset.PrependReactor("list", "ingresses", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		switch action.GetNamespace() {
+		case metav1.NamespaceAll:
+			return true, nil, apierrors.NewForbidden(ingressResource.GroupResource(), "", nil)
+		case "locked":
+			return true, nil, apierrors.NewForbidden(ingressResource.GroupResource(), "", nil)
+		}
+		return false, nil, nil
+	})
+
+	scanner := NewScanner(&Client{Clientset: clientset}, "")
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	if result.TotalIngresses != 2 {
+		t.Errorf("TotalIngresses = %d, want 2", result.TotalIngresses)
+	}
+	if len(result.ScanErrors) != 1 || result.ScanErrors[0].Stage != models.StageIngresses || result.ScanErrors[0].Namespace != "locked" {
+		t.Errorf("ScanErrors = %+v, want ingresses in locked", result.ScanErrors)
+	}
+}
+
+func TestListIngressesClusterWide(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		nginxIngress("shop", "web"),
+		nginxIngress("payments", "api"),
+	)
+	clientset.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		t.Error("namespaces should not be listed when cluster-wide list is allowed")
+		return false, nil, nil
+	})
+
+	scanner := NewScanner(&Client{Clientset: clientset}, "")
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+	if result.TotalIngresses != 2 || len(result.ScanErrors) != 0 {
+		t.Errorf("got %d ingresses and scan errors %+v, want 2 and none", result.TotalIngresses, result.ScanErrors)
+	}
+}
+
+func TestListIngressesAppliesNamespaceFilters(t *testing.T) {
+	team := map[string]string{"team": "payments"}
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments-api", Labels: team}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments-sandbox", Labels: team}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments-legacy"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		nginxIngress("payments-api", "api"),
+		nginxIngress("payments-sandbox", "api"),
+		nginxIngress("payments-legacy", "api"),
+		nginxIngress("shop", "web"),
+	)
+
+	scanner := NewScanner(&Client{Clientset: clientset}, "")
+	scanner.SetOptions(ScanOptions{
+		IncludeNamespaces: []string{"payments-*"},
+		ExcludeNamespaces: []string{"*-sandbox"},
+		NamespaceSelector: "team=payments",
+	})
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	if len(result.NginxIngresses) != 1 || result.NginxIngresses[0].Namespace != "payments-api" {
+		t.Errorf("NginxIngresses = %+v, want only payments-api/api", result.NginxIngresses)
+	}
+}
+
+func TestListIngressesAppliesIngressFilters(t *testing.T) {
+	labeled := func(namespace, name, partOf string) *networkingv1.Ingress {
+		ingress := nginxIngress(namespace, name)
+		ingress.Labels = map[string]string{"app.kubernetes.io/part-of": partOf}
+		return ingress
+	}
+	clientset := fake.NewSimpleClientset(
+		labeled("shop", "checkout-web", "checkout"),
+		labeled("shop", "checkout-api", "checkout"),
+		labeled("shop", "cart-web", "cart"),
+	)
+	var listOptions []metav1.ListOptions
+	clientset.PrependReactor("list", "ingresses", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		restrictions := action.(k8stesting.ListAction).GetListRestrictions()
+		listOptions = append(listOptions, metav1.ListOptions{LabelSelector: restrictions.Labels.String()})
+		return false, nil, nil
+	})
+
+	scanner := NewScanner(&Client{Clientset: clientset}, "")
+	scanner.SetOptions(ScanOptions{
+		Selector: "app.kubernetes.io/part-of=checkout",
+		Names:    []string{"*-web"},
+	})
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	if len(result.NginxIngresses) != 1 || result.NginxIngresses[0].Name != "checkout-web" {
+		t.Errorf("NginxIngresses = %+v, want only shop/checkout-web", result.NginxIngresses)
+	}
+	if len(listOptions) == 0 || listOptions[0].LabelSelector != "app.kubernetes.io/part-of=checkout" {
+		t.Errorf("list options = %+v, want the label selector applied server-side", listOptions)
+	}
+	if result.Filters == nil || result.Filters.Selector != "app.kubernetes.io/part-of=checkout" || len(result.Filters.Names) != 1 {
+		t.Errorf("Filters = %+v, want the selector and name pattern recorded", result.Filters)
+	}
+}
+
+func TestScanOptionsValidate(t *testing.T) {
+	if err := (ScanOptions{ExcludeNamespaces: []string{"kube-[system"}}).Validate(); err == nil {
+		t.Error("expected an error for a malformed namespace pattern")
+	}
+	if err := (ScanOptions{NamespaceSelector: "team in (payments"}).Validate(); err == nil {
+		t.Error("expected an error for a malformed namespace selector")
+	}
+	if err := (ScanOptions{Selector: "app in (checkout"}).Validate(); err == nil {
+		t.Error("expected an error for a malformed selector")
+	}
+}
+
+func TestScanResolvesIngressClasses(t *testing.T) {
+	ingressClass := func(name, controller string, isDefault bool) *networkingv1.IngressClass {
+		class := &networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       networkingv1.IngressClassSpec{Controller: controller},
+		}
+		if isDefault {
+			class.Annotations = map[string]string{defaultClassAnnotation: "true"}
+		}
+		return class
+	}
+	withClass := func(name, className string) *networkingv1.Ingress {
+		ingress := nginxIngress("shop", name)
+		ingress.Spec.IngressClassName = &className
+		return ingress
+	}
+	classless := nginxIngress("shop", "classless")
+	classless.Spec.IngressClassName = nil
+
+	clientset := fake.NewSimpleClientset(
+		ingressClass("nginx-internal", NginxControllerName, false),
+		ingressClass("nginx-public", "example.com/custom-nginx", true),
+		ingressClass("traefik", "traefik.io/ingress-controller", false),
+		withClass("internal", "nginx-internal"),
+		withClass("public", "nginx-public"),
+		withClass("other", "traefik"),
+		classless,
+	)
+
+	scanner := NewScanner(&Client{Clientset: clientset}, "")
+	scanner.SetOptions(ScanOptions{ControllerClasses: []string{"example.com/custom-nginx"}})
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	resolved := make(map[string]string)
+	for _, resource := range result.NginxIngresses {
+		resolved[resource.Name] = resource.ResolvedClass
+		if resource.ClassResolution == "" {
+			t.Errorf("%s has no class resolution reason", resource.Name)
+		}
+	}
+	want := map[string]string{
+		"internal":  "nginx-internal",
+		"public":    "nginx-public",
+		"classless": "nginx-public",
+	}
+	if len(resolved) != len(want) {
+		t.Fatalf("resolved %v, want %v", resolved, want)
+	}
+	for name, class := range want {
+		if resolved[name] != class {
+			t.Errorf("%s resolved to %q, want %q", name, resolved[name], class)
+		}
+	}
+}
+
+func TestClassResolverWithoutIngressClasses(t *testing.T) {
+	resolver := newClassResolver(nil, []string{rules.DefaultAnnotationPrefix})
+
+	if !resolver.resolve(*nginxIngress("shop", "web")).Nginx {
+		t.Error("class nginx should match by name when IngressClasses are unavailable")
+	}
+
+	other := nginxIngress("shop", "other")
+	traefik := "traefik"
+	other.Spec.IngressClassName = &traefik
+	if resolver.resolve(*other).Nginx {
+		t.Error("class traefik should not match")
+	}
+}
+
+func TestScanFindsControllerConfigMap(t *testing.T) {
+	controller := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-nginx", Name: "ingress-nginx-controller"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "controller",
+						Image: "registry.k8s.io/ingress-nginx/controller:v1.11.2",
+						Args: []string{
+							controllerBinary,
+							"--configmap=$(POD_NAMESPACE)/ingress-nginx-controller",
+							"--tcp-services-configmap", "$(POD_NAMESPACE)/tcp-services",
+						},
+					}},
+				},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-nginx", Name: "ingress-nginx-controller"},
+		Data:       map[string]string{"use-forwarded-headers": "true"},
+	}
+	tcpServices := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-nginx", Name: "tcp-services"},
+		Data: map[string]string{
+			"6379": "cache/redis:6379",
+			"9000": "cache/missing:9000::PROXY",
+		},
+	}
+	redis := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cache", Name: "redis"},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{
+			Name: "redis", Port: 6379, TargetPort: intstr.FromString("redis"),
+		}}},
+	}
+
+	clientset := fake.NewSimpleClientset(controller, configMap, tcpServices, redis)
+	scanner := NewScanner(&Client{Clientset: clientset}, "")
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	if len(result.ControllerConfigs) != 1 {
+		t.Fatalf("found %d controllers, want 1", len(result.ControllerConfigs))
+	}
+	config := result.ControllerConfigs[0]
+	if config.ConfigMap != "ingress-nginx/ingress-nginx-controller" || config.Data["use-forwarded-headers"] != "true" {
+		t.Errorf("unexpected controller config %+v", config)
+	}
+
+	if len(config.StreamServices) != 2 {
+		t.Fatalf("found %d stream services, want 2", len(config.StreamServices))
+	}
+	redisPort, missing := config.StreamServices[0], config.StreamServices[1]
+	if redisPort.Protocol != "TCP" || redisPort.Port != "6379" || redisPort.TargetPort != "redis" || redisPort.Error != "" {
+		t.Errorf("unexpected redis stream service %+v", redisPort)
+	}
+	if missing.ProxyProtocol != "encode" || missing.Error == "" {
+		t.Errorf("unexpected missing stream service %+v", missing)
+	}
+}
+
+func TestScanInventoriesControllers(t *testing.T) {
+	podTemplate := func(app string, args ...string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": app}},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "controller",
+					Image: "registry.k8s.io/ingress-nginx/controller:v1.11.2@sha256:d5f8217feeac4887cb1ed21f27c2674e58be06bd8f5184cacea2a69abaf78dce",
+					Args:  append([]string{controllerBinary}, args...),
+				}},
+			},
+		}
+	}
+	replicas := int32(3)
+	public := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-public", Name: "controller"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: podTemplate("public", "--enable-ssl-passthrough"),
+		},
+	}
+	internal := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-internal", Name: "controller"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: podTemplate("internal",
+				"--ingress-class=nginx-internal",
+				"--controller-class=k8s.io/ingress-nginx-internal",
+				"--watch-namespace=shop"),
+		},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 4, NumberReady: 4},
+	}
+	services := []runtime.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-public", Name: "controller"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, Selector: map[string]string{"app": "public"}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-public", Name: "controller-admission"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Selector: map[string]string{"app": "public"}},
+		},
+	}
+	internalClass := &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-internal"},
+		Spec:       networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx-internal"},
+	}
+	publicClass := &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+		Spec:       networkingv1.IngressClassSpec{Controller: NginxControllerName},
+	}
+	internalIngress := nginxIngress("shop", "internal")
+	internalClassName := "nginx-internal"
+	internalIngress.Spec.IngressClassName = &internalClassName
+
+	objects := append(services, public, internal, internalClass, publicClass,
+		nginxIngress("shop", "web"), internalIngress, nginxIngress("blog", "web"))
+	scanner := NewScanner(&Client{Clientset: fake.NewSimpleClientset(objects...)}, "")
+	scanner.SetOptions(ScanOptions{ControllerClasses: []string{"k8s.io/ingress-nginx-internal"}})
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	if len(result.Controllers) != 2 {
+		t.Fatalf("found %d controllers, want 2", len(result.Controllers))
+	}
+	internalController, publicController := result.Controllers[0], result.Controllers[1]
+	if internalController.Kind != "DaemonSet" || internalController.Replicas != 4 || internalController.WatchNamespace != "shop" {
+		t.Errorf("unexpected internal controller %+v", internalController)
+	}
+	if publicController.Version != "v1.11.2" || publicController.Replicas != 3 || !publicController.SSLPassthrough ||
+		publicController.ServiceType != "LoadBalancer" || publicController.IngressClass != "nginx" {
+		t.Errorf("unexpected public controller %+v", publicController)
+	}
+
+	servedBy := make(map[string][]string)
+	for _, resource := range result.NginxIngresses {
+		servedBy[resource.Namespace+"/"+resource.Name] = resource.ServedBy
+	}
+	want := map[string]string{
+		"shop/web":      "ingress-public/controller",
+		"shop/internal": "ingress-internal/controller",
+		"blog/web":      "ingress-public/controller",
+	}
+	for ingress, controller := range want {
+		if len(servedBy[ingress]) != 1 || servedBy[ingress][0] != controller {
+			t.Errorf("%s served by %v, want [%s]", ingress, servedBy[ingress], controller)
+		}
+	}
+}
+
+func TestScanDetectsControllerAnnotationPrefix(t *testing.T) {
+	controller := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress-internal", Name: "controller"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "controller",
+						Args: []string{controllerBinary, "--annotation-prefix=internal.ingress.example.com"},
+					}},
+				},
+			},
+		},
+	}
+	// Class-less Ingress only identifiable through the custom prefix
+	ingress := nginxIngress("shop", "web")
+	ingress.Spec.IngressClassName = nil
+	ingress.Annotations = map[string]string{"internal.ingress.example.com/rewrite-target": "/"}
+
+	scanner := NewScanner(&Client{Clientset: fake.NewSimpleClientset(controller, ingress)}, "")
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	if len(result.NginxIngresses) != 1 {
+		t.Fatalf("found %d ingress-nginx resources, want 1", len(result.NginxIngresses))
+	}
+	if prefix := result.NginxIngresses[0].AnnotationPrefix; prefix != "internal.ingress.example.com" {
+		t.Errorf("AnnotationPrefix = %q, want internal.ingress.example.com", prefix)
+	}
+}
+
+func TestConvertIngressKeepsRoutingTable(t *testing.T) {
+	ingress := nginxIngress("shop", "web")
+	prefix, exact := networkingv1.PathTypePrefix, networkingv1.PathTypeExact
+	apiGroup := "storage.example.com"
+	ingress.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: "fallback", Port: networkingv1.ServiceBackendPort{Number: 80}},
+	}
+	ingress.Spec.Rules = []networkingv1.IngressRule{
+		{
+			Host: "shop.example.com",
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{
+					{Path: "/api", PathType: &prefix, Backend: networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{Name: "api", Port: networkingv1.ServiceBackendPort{Name: "http"}},
+					}},
+					{Path: "/static", PathType: &exact, Backend: networkingv1.IngressBackend{
+						Resource: &corev1.TypedLocalObjectReference{APIGroup: &apiGroup, Kind: "Bucket", Name: "assets"},
+					}},
+				},
+			}},
+		},
+		{
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{
+					{Path: "/api", PathType: &prefix, Backend: networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{Name: "api-public", Port: networkingv1.ServiceBackendPort{Number: 8080}},
+					}},
+				},
+			}},
+		},
+	}
+
+	ingress.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{
+		{IP: "203.0.113.10"},
+		{Hostname: "lb.elb.amazonaws.com"},
+	}
+
+	resource := NewScanner(nil, "").convertIngress(*ingress)
+
+	want := []models.IngressRule{
+		{Host: "shop.example.com", Paths: []models.IngressPath{
+			{Path: "/api", PathType: "Prefix", Backend: models.BackendRef{Service: "api", Port: "http"}},
+			{Path: "/static", PathType: "Exact", Backend: models.BackendRef{Resource: "Bucket/assets"}},
+		}},
+		{Paths: []models.IngressPath{
+			{Path: "/api", PathType: "Prefix", Backend: models.BackendRef{Service: "api-public", Port: "8080"}},
+		}},
+	}
+	if !reflect.DeepEqual(resource.Rules, want) {
+		t.Errorf("Rules = %+v, want %+v", resource.Rules, want)
+	}
+	if resource.DefaultBackend == nil || *resource.DefaultBackend != (models.BackendRef{Service: "fallback", Port: "80"}) {
+		t.Errorf("DefaultBackend = %+v, want fallback:80", resource.DefaultBackend)
+	}
+	if !reflect.DeepEqual(resource.Paths, []string{"/api", "/static"}) {
+		t.Errorf("Paths = %v, want the deduplicated paths", resource.Paths)
+	}
+	if !reflect.DeepEqual(resource.Addresses, []string{"203.0.113.10", "lb.elb.amazonaws.com"}) {
+		t.Errorf("Addresses = %v, want the status load balancer IP and hostname", resource.Addresses)
+	}
+}
+
+func TestScanResolvesBackends(t *testing.T) {
+	ingress := nginxIngress("shop", "web")
+	pathType := networkingv1.PathTypePrefix
+	path := func(p, service string, port networkingv1.ServiceBackendPort) networkingv1.HTTPIngressPath {
+		return networkingv1.HTTPIngressPath{
+			Path:     p,
+			PathType: &pathType,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{Name: service, Port: port},
+			},
+		}
+	}
+	ingress.Spec.Rules = []networkingv1.IngressRule{{
+		Host: "shop.example.com",
+		IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+			Paths: []networkingv1.HTTPIngressPath{
+				path("/", "web", networkingv1.ServiceBackendPort{Number: 80}),
+				path("/api", "api", networkingv1.ServiceBackendPort{Name: "http"}),
+				path("/legacy", "legacy", networkingv1.ServiceBackendPort{Number: 80}),
+				path("/gone", "gone", networkingv1.ServiceBackendPort{Number: 80}),
+			},
+		}},
+	}}
+
+	ready := true
+	objects := []runtime.Object{
+		ingress,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Ports: []corev1.ServicePort{{
+				Port: 80, TargetPort: intstr.FromInt32(8080),
+			}}},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "shop", Name: "web-abc",
+				Labels: map[string]string{discoveryv1.LabelServiceName: "web"},
+			},
+			Endpoints: []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api"},
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Ports: []corev1.ServicePort{{
+				Name: "http", Port: 8000, TargetPort: intstr.FromString("http"),
+			}}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "legacy"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "legacy.example.net"},
+		},
+	}
+
+	scanner := NewScanner(&Client{Clientset: fake.NewSimpleClientset(objects...)}, "")
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	backends := result.NginxIngresses[0].Backends
+	if len(backends) != 4 {
+		t.Fatalf("got %d backends, want 4", len(backends))
+	}
+	web, api, legacy, gone := backends[0], backends[1], backends[2], backends[3]
+	if len(web.Issues) != 0 || web.ReadyEndpoints != 1 || web.TargetPort != "8080" {
+		t.Errorf("unexpected web backend %+v", web)
+	}
+	// Named port and no endpoints
+	if api.PortNumber != 8000 || len(api.Issues) != 2 {
+		t.Errorf("unexpected api backend %+v", api)
+	}
+	if legacy.ExternalName != "legacy.example.net" || len(legacy.Issues) != 1 {
+		t.Errorf("unexpected legacy backend %+v", legacy)
+	}
+	if !gone.Resolved || len(gone.Issues) != 1 {
+		t.Errorf("unexpected gone backend %+v", gone)
+	}
+}
+
+func selfSignedCertificate(t *testing.T, notAfter time.Time, dnsNames ...string) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestScanInspectsCertificates(t *testing.T) {
+	ingress := nginxIngress("shop", "web")
+	ingress.Spec.Rules = []networkingv1.IngressRule{{Host: "shop.example.com"}, {Host: "api.example.org"}}
+	ingress.Spec.TLS = []networkingv1.IngressTLS{
+		{Hosts: []string{"shop.example.com", "api.example.org"}, SecretName: "shop-tls"},
+		{Hosts: []string{"shop.example.com"}, SecretName: "missing-tls"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "shop-tls"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey: selfSignedCertificate(t, time.Now().Add(10*24*time.Hour), "*.example.com"),
+		},
+	}
+
+	scanner := NewScanner(&Client{Clientset: fake.NewSimpleClientset(ingress, secret)}, "")
+	scanner.SetOptions(ScanOptions{InspectCertificates: true})
+	result, err := scanner.ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+
+	tls := result.NginxIngresses[0].TLS
+	if len(tls) != 2 {
+		t.Fatalf("got %d TLS entries, want 2", len(tls))
+	}
+	if tls[0].Certificate == nil || tls[0].Certificate.DNSNames[0] != "*.example.com" {
+		t.Fatalf("certificate not parsed: %+v", tls[0])
+	}
+	// Expiring soon, and api.example.org is not covered by *.example.com
+	if len(tls[0].Issues) != 2 {
+		t.Errorf("issues = %v, want expiry and host coverage", tls[0].Issues)
+	}
+	if len(tls[1].Issues) != 1 || tls[1].Certificate != nil {
+		t.Errorf("missing secret not reported: %+v", tls[1])
+	}
+}
+
+func TestScanReadsNoSecretsByDefault(t *testing.T) {
+	ingress := nginxIngress("shop", "web")
+	ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{"shop.example.com"}, SecretName: "shop-tls"}}
+	clientset := fake.NewSimpleClientset(ingress)
+	clientset.PrependReactor("get", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		t.Errorf("Secret %s read without InspectCertificates", action.(k8stesting.GetAction).GetName())
+		return true, nil, nil
+	})
+
+	result, err := NewScanner(&Client{Clientset: clientset}, "").ScanCluster(context.Background())
+	if err != nil {
+		t.Fatalf("ScanCluster() error = %v", err)
+	}
+	if tls := result.NginxIngresses[0].TLS[0]; tls.Certificate != nil || len(tls.Issues) != 0 {
+		t.Errorf("TLS = %+v, want the certificate left unchecked", tls)
+	}
+}
+
+func TestWatchReportsIngressChanges(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+			Spec:       networkingv1.IngressClassSpec{Controller: NginxControllerName},
+		},
+		nginxIngress("shop", "web"),
+	)
+	scanner := NewScanner(&Client{Clientset: clientset}, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type change struct {
+		key      string
+		resource *models.IngressResource
+	}
+	changes := make(chan change, 10)
+	synced := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- scanner.Watch(ctx, WatchHandlers{
+			Ingress: func(key string, resource *models.IngressResource) {
+				changes <- change{key, resource}
+			},
+			Synced: func() { close(synced) },
+		})
+	}()
+
+	// Changes may repeat, e.g. when IngressClass events re-resolve Ingresses
+	waitFor := func(description string, match func(change) bool) {
+		t.Helper()
+		for {
+			select {
+			case c := <-changes:
+				if match(c) {
+					return
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for %s", description)
+			}
+		}
+	}
+
+	waitFor("shop/web resolved to "+NginxControllerName, func(c change) bool {
+		return c.key == "shop/web" && c.resource != nil && c.resource.Controller == NginxControllerName
+	})
+	select {
+	case <-synced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Synced")
+	}
+
+	updated := nginxIngress("shop", "web")
+	updated.Annotations = map[string]string{"nginx.ingress.kubernetes.io/configuration-snippet": "return 403;"}
+	if _, err := clientset.NetworkingV1().Ingresses("shop").Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	waitFor("the updated annotations", func(c change) bool {
+		return c.resource != nil && c.resource.Annotations["nginx.ingress.kubernetes.io/configuration-snippet"] != ""
+	})
+
+	if err := clientset.NetworkingV1().Ingresses("shop").Delete(ctx, "web", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	waitFor("the deletion", func(c change) bool {
+		return c.key == "shop/web" && c.resource == nil
+	})
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Watch() error = %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// findStreamServices reads the tcp-services and udp-services ConfigMaps a
+// controller references and resolves each exposed port to its Service
+func (s *Scanner) findStreamServices(ctx context.Context, args []string, namespace string) []models.StreamService {
+	var services []models.StreamService
+
+	for _, stream := range []struct {
+		protocol string
+		flag     string
+	}{
+		{"TCP", "tcp-services-configmap"},
+		{"UDP", "udp-services-configmap"},
+	} {
+		ref := resolveConfigMapRef(flagValue(args, stream.flag), namespace)
+		if ref == "" {
+			continue
+		}
+
+		data, err := s.readConfigMap(ctx, ref)
+		if err != nil {
+			services = append(services, models.StreamService{
+				Protocol:  stream.protocol,
+				ConfigMap: ref,
+				Error:     err.Error(),
+			})
+			continue
+		}
+
+		ports := make([]string, 0, len(data))
+		for port := range data {
+			ports = append(ports, port)
+		}
+		sort.Slice(ports, func(i, j int) bool {
+			a, _ := strconv.Atoi(ports[i])
+			b, _ := strconv.Atoi(ports[j])
+			return a < b
+		})
+
+		for _, port := range ports {
+			service := parseStreamService(stream.protocol, ref, port, data[port])
+			if service.Error == "" {
+				s.resolveStreamService(ctx, &service)
+			}
+			services = append(services, service)
+		}
+	}
+
+	return services
+}
+
+// parseStreamService parses a services ConfigMap entry of the form
+// "<port>: <namespace>/<service>:<port>[:PROXY][:PROXY]", where the PROXY
+// fields enable PROXY protocol decoding and encoding
+func parseStreamService(protocol, configMap, port, value string) models.StreamService {
+	service := models.StreamService{
+		Protocol:  protocol,
+		Port:      port,
+		ConfigMap: configMap,
+	}
+
+	fields := strings.Split(strings.TrimSpace(value), ":")
+	namespace, name, found := strings.Cut(fields[0], "/")
+	if !found || len(fields) < 2 || name == "" || fields[1] == "" {
+		service.Error = fmt.Sprintf("invalid entry %q, expected <namespace>/<service>:<port>", value)
+		return service
+	}
+	service.Namespace = namespace
+	service.Service = name
+	service.ServicePort = fields[1]
+
+	var proxy []string
+	if len(fields) > 2 && fields[2] == "PROXY" {
+		proxy = append(proxy, "decode")
+	}
+	if len(fields) > 3 && fields[3] == "PROXY" {
+		proxy = append(proxy, "encode")
+	}
+	service.ProxyProtocol = strings.Join(proxy, ",")
+
+	return service
+}
+
+// resolveStreamService looks up the target Service and port of an entry
+func (s *Scanner) resolveStreamService(ctx context.Context, service *models.StreamService) {
+	svc, err := callAPI(ctx, func(ctx context.Context) (*corev1.Service, error) {
+		return s.client.Clientset.CoreV1().Services(service.Namespace).Get(ctx, service.Service, metav1.GetOptions{})
+	})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			s.recordError(models.StageStreamServices, service.Namespace, err, "TCP/UDP stream services targeting this namespace were not resolved")
+		}
+		service.Error = fmt.Sprintf("failed to read service %s/%s: %v", service.Namespace, service.Service, err)
+		return
+	}
+
+	port := findServicePort(svc.Spec.Ports, service.ServicePort)
+	if port == nil {
+		service.Error = fmt.Sprintf("service %s/%s has no port %s", service.Namespace, service.Service, service.ServicePort)
+		return
+	}
+	service.TargetPort = port.TargetPort.String()
+	if service.TargetPort == "" || service.TargetPort == "0" {
+		service.TargetPort = strconv.Itoa(int(port.Port))
+	}
+}
+
+// findServicePort finds a Service port by number or name
+func findServicePort(ports []corev1.ServicePort, ref string) *corev1.ServicePort {
+	for i, port := range ports {
+		if strconv.Itoa(int(port.Port)) == ref || (port.Name != "" && port.Name == ref) {
+			return &ports[i]
+		}
+	}
+	return nil
+}
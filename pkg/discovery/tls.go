@@ -0,0 +1,163 @@
+package discovery
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// certificateExpiryWarning is how far ahead an upcoming expiry is reported
+const certificateExpiryWarning = 30 * 24 * time.Hour
+
+// extractTLS extracts the spec.tls entries of an Ingress. Entries without
+// hosts apply to all hosts of the Ingress.
+func extractTLS(ingress networkingv1.Ingress, hosts []string) []models.TLSConfig {
+	var configs []models.TLSConfig
+	for _, tls := range ingress.Spec.TLS {
+		config := models.TLSConfig{
+			Hosts:      tls.Hosts,
+			SecretName: tls.SecretName,
+		}
+		if len(config.Hosts) == 0 {
+			config.Hosts = hosts
+		}
+		if config.SecretName == "" {
+			config.Issues = append(config.Issues,
+				"no secretName: served with the controller's default certificate; the Gateway listener needs explicit certificateRefs")
+		}
+		configs = append(configs, config)
+	}
+	return configs
+}
+
+// secretCertificate is the certificate parsed from a TLS Secret, or the
+// issue that prevented reading it
+type secretCertificate struct {
+	certificate *models.Certificate
+	issue       string
+}
+
+// inspectCertificates reads the TLS Secrets referenced by every Ingress and
+// checks their certificates. Only tls.crt is parsed; private keys are ignored.
+func (s *Scanner) inspectCertificates(ctx context.Context, resources []models.IngressResource) {
+	now := time.Now()
+	certificates := make(map[string]secretCertificate)
+
+	for i := range resources {
+		resource := &resources[i]
+		for j := range resource.TLS {
+			tls := &resource.TLS[j]
+			if tls.SecretName == "" {
+				continue
+			}
+
+			key := resource.Namespace + "/" + tls.SecretName
+			result, cached := certificates[key]
+			if !cached {
+				result = s.readCertificate(ctx, resource.Namespace, tls.SecretName)
+				certificates[key] = result
+			}
+
+			if result.certificate == nil {
+				tls.Issues = append(tls.Issues, result.issue)
+				continue
+			}
+			tls.Certificate = result.certificate
+			tls.Issues = append(tls.Issues, checkCertificate(tls.Certificate, tls.Hosts, now)...)
+		}
+	}
+}
+
+// readCertificate reads a TLS Secret and parses its certificate. The API
+// server returns the whole Secret, tls.key included; only tls.crt is kept. The Secret,
+// private key included, is not kept once the certificate is parsed.
+func (s *Scanner) readCertificate(ctx context.Context, namespace, name string) secretCertificate {
+	secret, err := callAPI(ctx, func(ctx context.Context) (*corev1.Secret, error) {
+		return s.client.Clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	switch {
+	case apierrors.IsNotFound(err):
+		return secretCertificate{issue: fmt.Sprintf("Secret %s not found", name)}
+	case err != nil:
+		s.recordError(models.StageTLSSecrets, namespace, err, "TLS certificates of Ingresses in this namespace were not checked")
+		return secretCertificate{issue: fmt.Sprintf("could not read Secret %s: %v", name, err)}
+	}
+
+	certificate, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return secretCertificate{issue: fmt.Sprintf("Secret %s: %v", name, err)}
+	}
+	return secretCertificate{certificate: certificate}
+}
+
+// parseCertificate parses the leaf certificate of a PEM bundle
+func parseCertificate(data []byte) (*models.Certificate, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no %s", corev1.TLSCertKey)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%s is not a PEM certificate", corev1.TLSCertKey)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", corev1.TLSCertKey, err)
+	}
+
+	return &models.Certificate{
+		Subject:   cert.Subject.String(),
+		Issuer:    cert.Issuer.String(),
+		DNSNames:  cert.DNSNames,
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+	}, nil
+}
+
+// checkCertificate reports expiry problems and hosts the certificate does not cover
+func checkCertificate(cert *models.Certificate, hosts []string, now time.Time) []string {
+	var issues []string
+
+	switch {
+	case now.After(cert.NotAfter):
+		issues = append(issues, fmt.Sprintf("certificate expired on %s", cert.NotAfter.Format("2006-01-02")))
+	case cert.NotAfter.Sub(now) < certificateExpiryWarning:
+		issues = append(issues, fmt.Sprintf("certificate expires on %s", cert.NotAfter.Format("2006-01-02")))
+	}
+
+	for _, host := range hosts {
+		if !certificateCovers(cert.DNSNames, host) {
+			issues = append(issues, fmt.Sprintf("certificate does not cover host %s", host))
+		}
+	}
+
+	return issues
+}
+
+// certificateCovers reports whether any SAN matches host, where a wildcard
+// SAN matches exactly one leftmost label
+func certificateCovers(dnsNames []string, host string) bool {
+	host = strings.ToLower(host)
+	for _, name := range dnsNames {
+		name = strings.ToLower(name)
+		if name == host {
+			return true
+		}
+		if suffix, wildcard := strings.CutPrefix(name, "*."); wildcard {
+			if label, rest, found := strings.Cut(host, "."); found && label != "" && rest == suffix {
+				return true
+			}
+		}
+	}
+	return false
+}
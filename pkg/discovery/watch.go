@@ -0,0 +1,299 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// WatchHandlers receives the changes found by Scanner.Watch. Calls are
+// serialized, so handlers need no locking.
+type WatchHandlers struct {
+	// Ingress is called with the current state of a changed Ingress, keyed
+	// by namespace/name. resource is nil when the Ingress was deleted or is
+	// no longer served by ingress-nginx.
+	Ingress func(key string, resource *models.IngressResource)
+
+	// ControllerConfig is called when a controller's ConfigMap changes
+	ControllerConfig func(config models.ControllerConfig)
+
+	// Synced is called once, after the initial state of every watched
+	// object has been delivered
+	Synced func()
+}
+
+// watchKind identifies what a queued watch item refers to
+type watchKind int
+
+const (
+	watchIngress watchKind = iota
+	watchIngressClasses
+	watchConfigMap
+	watchSynced
+)
+
+// watchItem is a unit of work of the watch queue
+type watchItem struct {
+	kind watchKind
+	key  string // namespace/name for Ingresses and ConfigMaps
+}
+
+// watcher holds the informer state of a running Scanner.Watch
+type watcher struct {
+	scanner     *Scanner
+	handlers    WatchHandlers
+	queue       *workqueue.Typed[watchItem]
+	filter      *ingressFilter
+	controllers []models.IngressController
+	configs     []models.ControllerConfig
+	prefixes    []string
+	resolver    *classResolver
+
+	ingresses  cache.Indexer
+	classes    cache.Indexer // nil when IngressClasses cannot be listed
+	configMaps map[string]cache.Indexer
+}
+
+// Watch watches Ingresses, IngressClasses and the controller ConfigMaps
+// with shared informers and reports every change to handlers until ctx is
+// done. Controller workloads and the namespace label selector are read once
+// at start.
+func (s *Scanner) Watch(ctx context.Context, handlers WatchHandlers) error {
+	if s.client == nil {
+		return fmt.Errorf("watch mode requires cluster access")
+	}
+
+	filter, err := s.newIngressFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := &watcher{
+		scanner:    s,
+		handlers:   handlers,
+		queue:      workqueue.NewTyped[watchItem](),
+		filter:     filter,
+		configMaps: make(map[string]cache.Indexer),
+	}
+	defer w.queue.ShutDown()
+
+	w.controllers, w.configs, err = s.findControllers(ctx)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v; skipping controller analysis\n", err)
+	}
+	w.prefixes = s.annotationPrefixes(w.controllers)
+	w.resolver = newClassResolver(s.options.ControllerClasses, w.prefixes)
+
+	var registrations []cache.ResourceEventHandlerRegistration
+	var factories []informers.SharedInformerFactory
+	var classesSynced cache.InformerSynced
+
+	// Ingresses, scoped the same way as a scan
+	ingressFactory := informers.NewSharedInformerFactoryWithOptions(s.client.Clientset, 0,
+		informers.WithNamespace(s.namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			listOptions := s.ingressListOptions()
+			opts.LabelSelector = listOptions.LabelSelector
+			opts.FieldSelector = listOptions.FieldSelector
+		}))
+	ingressInformer := ingressFactory.Networking().V1().Ingresses().Informer()
+	registration, err := ingressInformer.AddEventHandler(w.enqueueHandler(func(obj interface{}) watchItem {
+		key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		return watchItem{kind: watchIngress, key: key}
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to watch ingresses: %w", err)
+	}
+	w.ingresses = ingressInformer.GetIndexer()
+	registrations = append(registrations, registration)
+	factories = append(factories, ingressFactory)
+
+	// IngressClasses, when the cluster lets us list them
+	if _, err := s.listIngressClasses(ctx); err != nil {
+		fmt.Printf("⚠️  Warning: %v; matching ingress classes by name\n", err)
+	} else {
+		classFactory := informers.NewSharedInformerFactory(s.client.Clientset, 0)
+		classInformer := classFactory.Networking().V1().IngressClasses().Informer()
+		registration, err := classInformer.AddEventHandler(w.enqueueHandler(func(interface{}) watchItem {
+			return watchItem{kind: watchIngressClasses}
+		}))
+		if err != nil {
+			return fmt.Errorf("failed to watch ingress classes: %w", err)
+		}
+		w.classes = classInformer.GetIndexer()
+		classesSynced = classInformer.HasSynced
+		registrations = append(registrations, registration)
+		factories = append(factories, classFactory)
+	}
+
+	// The ConfigMap of each controller
+	for _, config := range w.configs {
+		if config.ConfigMap == "" || w.configMaps[config.ConfigMap] != nil {
+			continue
+		}
+		namespace, name, _ := strings.Cut(config.ConfigMap, "/")
+		configMapFactory := informers.NewSharedInformerFactoryWithOptions(s.client.Clientset, 0,
+			informers.WithNamespace(namespace),
+			informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+				opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+			}))
+		configMapInformer := configMapFactory.Core().V1().ConfigMaps().Informer()
+		registration, err := configMapInformer.AddEventHandler(w.enqueueHandler(func(obj interface{}) watchItem {
+			key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			return watchItem{kind: watchConfigMap, key: key}
+		}))
+		if err != nil {
+			return fmt.Errorf("failed to watch configmap %s: %w", config.ConfigMap, err)
+		}
+		w.configMaps[config.ConfigMap] = configMapInformer.GetIndexer()
+		registrations = append(registrations, registration)
+		factories = append(factories, configMapFactory)
+	}
+
+	for _, factory := range factories {
+		factory.Start(ctx.Done())
+		defer factory.Shutdown()
+	}
+
+	// Resolve the initial Ingresses against the initial IngressClasses
+	if classesSynced != nil {
+		if !cache.WaitForCacheSync(ctx.Done(), classesSynced) {
+			return nil
+		}
+		w.rebuildResolver()
+	}
+
+	go func() {
+		syncs := make([]cache.InformerSynced, 0, len(registrations))
+		for _, registration := range registrations {
+			syncs = append(syncs, registration.HasSynced)
+		}
+		// Queued after every initial event, so Synced follows the initial state
+		if cache.WaitForCacheSync(ctx.Done(), syncs...) {
+			w.queue.Add(watchItem{kind: watchSynced})
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		w.queue.ShutDown()
+	}()
+
+	for {
+		item, shutdown := w.queue.Get()
+		if shutdown {
+			return nil
+		}
+		w.process(item)
+		w.queue.Done(item)
+	}
+}
+
+// enqueueHandler returns an event handler that queues the item for every
+// add, update and delete of an object
+func (w *watcher) enqueueHandler(item func(obj interface{}) watchItem) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.queue.Add(item(obj)) },
+		UpdateFunc: func(_, obj interface{}) { w.queue.Add(item(obj)) },
+		DeleteFunc: func(obj interface{}) { w.queue.Add(item(obj)) },
+	}
+}
+
+// process handles a single queued item
+func (w *watcher) process(item watchItem) {
+	switch item.kind {
+	case watchIngress:
+		w.processIngress(item.key)
+	case watchIngressClasses:
+		w.processIngressClasses()
+	case watchConfigMap:
+		w.processConfigMap(item.key)
+	case watchSynced:
+		if w.handlers.Synced != nil {
+			w.handlers.Synced()
+		}
+	}
+}
+
+// processIngress reports the current state of an Ingress
+func (w *watcher) processIngress(key string) {
+	if w.handlers.Ingress == nil {
+		return
+	}
+
+	obj, exists, err := w.ingresses.GetByKey(key)
+	if err != nil || !exists {
+		w.handlers.Ingress(key, nil)
+		return
+	}
+
+	ingress := *obj.(*networkingv1.Ingress)
+	if !w.filter.matchesIngress(ingress) {
+		w.handlers.Ingress(key, nil)
+		return
+	}
+	resolution := w.resolver.resolve(ingress)
+	if !resolution.Nginx {
+		w.handlers.Ingress(key, nil)
+		return
+	}
+
+	resources := []models.IngressResource{w.scanner.newIngressResource(SourcedIngress{Ingress: ingress}, resolution)}
+	attributeControllers(resources, w.controllers)
+	assignAnnotationPrefixes(resources, w.controllers, w.scanner.options.AnnotationPrefix, w.prefixes)
+	w.handlers.Ingress(key, &resources[0])
+}
+
+// processIngressClasses rebuilds the class resolver and re-resolves every
+// Ingress, since a class change can move Ingresses to or from ingress-nginx
+func (w *watcher) processIngressClasses() {
+	w.rebuildResolver()
+	for _, key := range w.ingresses.ListKeys() {
+		w.queue.Add(watchItem{kind: watchIngress, key: key})
+	}
+}
+
+// rebuildResolver rebuilds the class resolver from the cached IngressClasses
+func (w *watcher) rebuildResolver() {
+	var classes []networkingv1.IngressClass
+	for _, obj := range w.classes.List() {
+		classes = append(classes, *obj.(*networkingv1.IngressClass))
+	}
+	w.resolver = newClassResolver(w.scanner.options.ControllerClasses, w.prefixes)
+	w.resolver.addClasses(classes)
+}
+
+// processConfigMap reports the controllers whose ConfigMap changed
+func (w *watcher) processConfigMap(key string) {
+	indexer := w.configMaps[key]
+	if indexer == nil || w.handlers.ControllerConfig == nil {
+		return
+	}
+
+	var data map[string]string
+	var configErr string
+	if obj, exists, _ := indexer.GetByKey(key); exists {
+		data = obj.(*corev1.ConfigMap).Data
+	} else {
+		configErr = fmt.Sprintf("configmap %s not found", key)
+	}
+
+	for i := range w.configs {
+		if w.configs[i].ConfigMap != key {
+			continue
+		}
+		w.configs[i].Data = data
+		w.configs[i].Error = configErr
+		w.handlers.ControllerConfig(w.configs[i])
+	}
+}
@@ -0,0 +1,207 @@
+package render
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+
+	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/discovery"
+)
+
+// DefaultReleaseName matches the release name used by `helm template`
+const DefaultReleaseName = "release-name"
+
+// HelmOptions configures in-process rendering of a local Helm chart
+type HelmOptions struct {
+	ChartDir    string
+	ValuesFiles []string
+	ReleaseName string
+	Namespace   string
+}
+
+// valuesLayer is one source of chart values, in increasing precedence
+type valuesLayer struct {
+	file   string
+	values map[string]interface{}
+}
+
+// HelmLoader returns a loader that renders the chart and yields its Ingresses
+func HelmLoader(opts HelmOptions) discovery.ManifestLoader {
+	return func() ([]discovery.SourcedIngress, error) {
+		return RenderHelmChart(opts)
+	}
+}
+
+// RenderHelmChart renders a local chart with the given values files, the same
+// way `helm template` would, and returns every networking.k8s.io/v1 Ingress.
+// Each Ingress records the chart, template and the values file that set each
+// of its annotations.
+func RenderHelmChart(opts HelmOptions) ([]discovery.SourcedIngress, error) {
+	chrt, err := loader.Load(opts.ChartDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart %s: %w", opts.ChartDir, err)
+	}
+
+	// Merge values files in order, later files taking precedence
+	layers := []valuesLayer{{file: filepath.Join(opts.ChartDir, chartutil.ValuesfileName), values: chrt.Values}}
+	userValues := map[string]interface{}{}
+	for _, file := range opts.ValuesFiles {
+		values, err := chartutil.ReadValuesFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file %s: %w", file, err)
+		}
+		userValues = mergeValues(userValues, values)
+		layers = append(layers, valuesLayer{file: file, values: values})
+	}
+
+	if err := chartutil.ProcessDependenciesWithMerge(chrt, userValues); err != nil {
+		return nil, fmt.Errorf("failed to process chart dependencies: %w", err)
+	}
+
+	releaseName := opts.ReleaseName
+	if releaseName == "" {
+		releaseName = DefaultReleaseName
+	}
+	releaseNamespace := opts.Namespace
+	if releaseNamespace == "" {
+		releaseNamespace = "default"
+	}
+
+	releaseOptions := chartutil.ReleaseOptions{
+		Name:      releaseName,
+		Namespace: releaseNamespace,
+		Revision:  1,
+		IsInstall: true,
+	}
+	renderValues, err := chartutil.ToRenderValues(chrt, userValues, releaseOptions, chartutil.DefaultCapabilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute render values: %w", err)
+	}
+
+	rendered, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chart %s: %w", opts.ChartDir, err)
+	}
+
+	// Sort template names for stable output
+	templates := make([]string, 0, len(rendered))
+	for name := range rendered {
+		if isManifestTemplate(name) {
+			templates = append(templates, name)
+		}
+	}
+	sort.Strings(templates)
+
+	var ingresses []discovery.SourcedIngress
+	for _, name := range templates {
+		origin := &models.ResourceOrigin{
+			Chart:       opts.ChartDir,
+			Release:     releaseName,
+			ValuesFiles: opts.ValuesFiles,
+			Template:    name,
+		}
+		items, err := discovery.DecodeManifests(strings.NewReader(rendered[name]), origin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode rendered template %s: %w", name, err)
+		}
+		for i := range items {
+			items[i].Origin.AnnotationSources = annotationSources(items[i].Ingress.Annotations, layers)
+		}
+		ingresses = append(ingresses, items...)
+	}
+
+	return ingresses, nil
+}
+
+// isManifestTemplate reports whether a rendered template can contain manifests
+func isManifestTemplate(name string) bool {
+	base := filepath.Base(name)
+	if strings.HasPrefix(base, "_") {
+		return false // partials
+	}
+	switch strings.ToLower(filepath.Ext(base)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// mergeValues deep-merges src into dst, with src taking precedence
+func mergeValues(dst, src map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(dst))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range src {
+		if srcMap, ok := v.(map[string]interface{}); ok {
+			if dstMap, ok := out[k].(map[string]interface{}); ok {
+				out[k] = mergeValues(dstMap, srcMap)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// annotationSources attributes each annotation to the highest-precedence
+// values file that sets it, e.g. "values-prod.yaml (ingress.annotations)".
+// Annotations hard-coded in templates are left out.
+func annotationSources(annotations map[string]string, layers []valuesLayer) map[string]string {
+	sources := make(map[string]string)
+
+	for key, value := range annotations {
+		for i := len(layers) - 1; i >= 0; i-- {
+			if path, ok := findValue(layers[i].values, key, value, ""); ok {
+				if path != "" {
+					sources[key] = fmt.Sprintf("%s (%s)", layers[i].file, path)
+				} else {
+					sources[key] = layers[i].file
+				}
+				break
+			}
+		}
+	}
+
+	if len(sources) == 0 {
+		return nil
+	}
+	return sources
+}
+
+// findValue searches values for a map entry key: value and returns the
+// dotted path of the map that contains it
+func findValue(values map[string]interface{}, key, value, path string) (string, bool) {
+	if v, ok := values[key]; ok && fmt.Sprint(v) == value {
+		return path, true
+	}
+
+	// Walk nested maps in a stable order
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		nested, ok := values[k].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		childPath := k
+		if path != "" {
+			childPath = path + "." + k
+		}
+		if found, ok := findValue(nested, key, value, childPath); ok {
+			return found, true
+		}
+	}
+
+	return "", false
+}
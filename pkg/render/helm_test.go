@@ -0,0 +1,50 @@
+package render
+
+import (
+	"testing"
+)
+
+func TestRenderHelmChart(t *testing.T) {
+	ingresses, err := RenderHelmChart(HelmOptions{
+		ChartDir:    "testdata/web-app",
+		ValuesFiles: []string{"testdata/web-app/values-prod.yaml"},
+		ReleaseName: "shop",
+		Namespace:   "payments",
+	})
+	if err != nil {
+		t.Fatalf("RenderHelmChart() error = %v", err)
+	}
+
+	if len(ingresses) != 1 {
+		t.Fatalf("RenderHelmChart() returned %d ingresses, want 1", len(ingresses))
+	}
+
+	ingress := ingresses[0].Ingress
+	if ingress.Name != "shop-web-app" || ingress.Namespace != "payments" {
+		t.Errorf("rendered %s/%s, want payments/shop-web-app", ingress.Namespace, ingress.Name)
+	}
+	if got := ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"]; got != "64m" {
+		t.Errorf("proxy-body-size = %q, want the values-prod.yaml override 64m", got)
+	}
+
+	origin := ingresses[0].Origin
+	if origin == nil || origin.Chart != "testdata/web-app" || origin.Template != "web-app/templates/ingress.yaml" {
+		t.Fatalf("unexpected origin %+v", origin)
+	}
+
+	wantSources := map[string]string{
+		"nginx.ingress.kubernetes.io/ssl-redirect":          "testdata/web-app/values.yaml (ingress.annotations)",
+		"nginx.ingress.kubernetes.io/proxy-body-size":       "testdata/web-app/values-prod.yaml (ingress.annotations)",
+		"nginx.ingress.kubernetes.io/configuration-snippet": "testdata/web-app/values-prod.yaml (ingress.annotations)",
+	}
+	for key, want := range wantSources {
+		if got := origin.AnnotationSources[key]; got != want {
+			t.Errorf("AnnotationSources[%s] = %q, want %q", key, got, want)
+		}
+	}
+
+	// Hard-coded in the template, not set by any values file
+	if source, ok := origin.AnnotationSources["nginx.ingress.kubernetes.io/use-regex"]; ok {
+		t.Errorf("use-regex attributed to %q, want no values file", source)
+	}
+}
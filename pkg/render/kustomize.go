@@ -0,0 +1,59 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/discovery"
+)
+
+// KustomizeLoader returns a loader that builds each kustomization directory
+// and yields the resulting Ingresses
+func KustomizeLoader(overlays []string) discovery.ManifestLoader {
+	return func() ([]discovery.SourcedIngress, error) {
+		var ingresses []discovery.SourcedIngress
+		for _, overlay := range overlays {
+			items, err := BuildKustomization(overlay)
+			if err != nil {
+				return nil, err
+			}
+			ingresses = append(ingresses, items...)
+		}
+		return ingresses, nil
+	}
+}
+
+// BuildKustomization builds a kustomization directory in-process, the same
+// way `kustomize build` would, and returns every networking.k8s.io/v1 Ingress.
+// Each Ingress records the overlay it came from.
+func BuildKustomization(dir string) ([]discovery.SourcedIngress, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := kustomizer.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization %s: %w", dir, err)
+	}
+
+	var ingresses []discovery.SourcedIngress
+	for _, res := range resMap.Resources() {
+		if res.GetKind() != "Ingress" {
+			continue
+		}
+
+		data, err := res.AsYAML()
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize %s from %s: %w", res.CurId(), dir, err)
+		}
+
+		items, err := discovery.DecodeManifests(bytes.NewReader(data), &models.ResourceOrigin{Overlay: dir})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s from %s: %w", res.CurId(), dir, err)
+		}
+		ingresses = append(ingresses, items...)
+	}
+
+	return ingresses, nil
+}
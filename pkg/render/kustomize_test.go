@@ -0,0 +1,36 @@
+package render
+
+import (
+	"testing"
+)
+
+func TestBuildKustomization(t *testing.T) {
+	base, err := BuildKustomization("testdata/kustomize/base")
+	if err != nil {
+		t.Fatalf("BuildKustomization(base) error = %v", err)
+	}
+	prod, err := BuildKustomization("testdata/kustomize/overlays/prod")
+	if err != nil {
+		t.Fatalf("BuildKustomization(prod) error = %v", err)
+	}
+
+	if len(base) != 1 || len(prod) != 1 {
+		t.Fatalf("got %d base and %d prod ingresses, want 1 each", len(base), len(prod))
+	}
+
+	ingress := prod[0].Ingress
+	if ingress.Namespace != "shop" || ingress.Name != "prod-storefront" {
+		t.Errorf("prod overlay built %s/%s, want shop/prod-storefront", ingress.Namespace, ingress.Name)
+	}
+	if _, ok := ingress.Annotations["nginx.ingress.kubernetes.io/configuration-snippet"]; !ok {
+		t.Error("prod overlay patch did not add configuration-snippet")
+	}
+	if _, ok := base[0].Ingress.Annotations["nginx.ingress.kubernetes.io/configuration-snippet"]; ok {
+		t.Error("base should not have configuration-snippet")
+	}
+
+	origin := prod[0].Origin
+	if origin.Overlay != "testdata/kustomize/overlays/prod" {
+		t.Errorf("unexpected origin %+v", origin)
+	}
+}
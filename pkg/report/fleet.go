@@ -0,0 +1,137 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/analyze"
+)
+
+// GenerateFleetReport creates one markdown report with a fleet-wide summary
+// followed by a section per cluster
+func (m *MarkdownGenerator) GenerateFleetReport(fleet *models.FleetAnalysis, outputDir string) (string, error) {
+	var content strings.Builder
+
+	m.writeFleetHeader(&content, fleet)
+	m.writeFleetSummary(&content, fleet)
+	m.writeFleetAnnotations(&content, fleet)
+
+	for _, cluster := range fleet.Clusters {
+		if cluster.Analysis == nil {
+			continue
+		}
+		content.WriteString(fmt.Sprintf("## Cluster: %s\n\n", cluster.Context))
+
+		// Reuse the single-cluster report, nested two heading levels down
+		clusterReport := &MarkdownGenerator{ContextName: cluster.Context}
+		content.WriteString(DemoteHeadings(clusterReport.generateReportContent(cluster.Analysis), 2))
+		content.WriteString("\n")
+	}
+
+	timestamp := time.Now().Format("2006-01-02-150405")
+	filename := fmt.Sprintf("fleet-report-%s.md", timestamp)
+	filepath := filepath.Join(outputDir, filename)
+
+	if err := os.WriteFile(filepath, []byte(content.String()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return filepath, nil
+}
+
+// writeFleetHeader writes the fleet report header
+func (m *MarkdownGenerator) writeFleetHeader(content *strings.Builder, fleet *models.FleetAnalysis) {
+	content.WriteString("# Ingress-NGINX Fleet Migration Report\n\n")
+	content.WriteString(fmt.Sprintf("**Generated**: %s\n", fleet.ScanTime.Format("2006-01-02 15:04:05")))
+	content.WriteString(fmt.Sprintf("**Clusters**: %d", fleet.Summary.Clusters))
+	if fleet.Summary.FailedClusters > 0 {
+		content.WriteString(fmt.Sprintf(" (%d could not be analyzed)", fleet.Summary.FailedClusters))
+	}
+	content.WriteString("\n")
+	content.WriteString(fmt.Sprintf("**Ingress-NGINX Resources**: %d\n", fleet.Summary.TotalIngresses))
+	content.WriteString("\n---\n\n")
+}
+
+// writeFleetSummary writes fleet-wide risk totals and a per-cluster table
+func (m *MarkdownGenerator) writeFleetSummary(content *strings.Builder, fleet *models.FleetAnalysis) {
+	summary := fleet.Summary
+	content.WriteString("## Fleet Summary\n\n")
+
+	if total := summary.TotalIngresses; total > 0 {
+		content.WriteString("| Risk Level | Count | Percentage |\n")
+		content.WriteString("|------------|-------|------------|\n")
+		content.WriteString(fmt.Sprintf("| ✅ AUTO-MIGRATABLE | %d | %.0f%% |\n", summary.AutoCount, float64(summary.AutoCount)/float64(total)*100))
+		content.WriteString(fmt.Sprintf("| ⚠️ MANUAL REVIEW | %d | %.0f%% |\n", summary.ManualCount, float64(summary.ManualCount)/float64(total)*100))
+		content.WriteString(fmt.Sprintf("| ❌ HIGH RISK | %d | %.0f%% |\n\n", summary.HighRiskCount, float64(summary.HighRiskCount)/float64(total)*100))
+	}
+
+	content.WriteString("| Cluster | Version | Ingress-NGINX | AUTO | MANUAL | HIGH | Status |\n")
+	content.WriteString("|---------|---------|---------------|------|--------|------|--------|\n")
+	for _, cluster := range fleet.Clusters {
+		if cluster.Analysis == nil {
+			content.WriteString(fmt.Sprintf("| %s | - | - | - | - | - | ❌ %s |\n", cluster.Context, cluster.Error))
+			continue
+		}
+		s := cluster.Analysis.Summary
+		status := "✅"
+		if scanErrors := cluster.Analysis.ScanResult.ScanErrors; len(scanErrors) > 0 {
+			status = fmt.Sprintf("⚠️ incomplete (%d scan errors)", len(scanErrors))
+		}
+		content.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %d | %d | %s |\n",
+			cluster.Context, cluster.Analysis.ScanResult.ClusterVersion, s.TotalIngresses,
+			s.AutoCount, s.ManualCount, s.HighRiskCount, status))
+	}
+	content.WriteString("\n")
+}
+
+// writeFleetAnnotations writes ingress-nginx annotation usage across the fleet
+func (m *MarkdownGenerator) writeFleetAnnotations(content *strings.Builder, fleet *models.FleetAnalysis) {
+	if len(fleet.Summary.Annotations) == 0 {
+		return
+	}
+
+	content.WriteString("## Annotation Usage Across the Fleet\n\n")
+	content.WriteString("| Annotation | Risk | Ingresses | Clusters |\n")
+	content.WriteString("|------------|------|-----------|----------|\n")
+	for _, annotation := range fleet.Summary.Annotations {
+		content.WriteString(fmt.Sprintf("| `%s` | %s %s | %d | %d (%s) |\n",
+			annotation.Key, analyze.GetRiskLevelIcon(annotation.Risk), annotation.Risk,
+			annotation.UsageCount, len(annotation.Clusters), strings.Join(annotation.Clusters, ", ")))
+	}
+	content.WriteString("\n")
+}
+
+// DemoteHeadings nests markdown content by adding levels to every heading
+func DemoteHeadings(content string, levels int) string {
+	prefix := strings.Repeat("#", levels)
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GenerateFleetReport creates a JSON report of a fleet analysis
+func (j *JSONGenerator) GenerateFleetReport(fleet *models.FleetAnalysis, outputDir string) (string, error) {
+	timestamp := time.Now().Format("2006-01-02-150405")
+	filename := fmt.Sprintf("fleet-report-%s.json", timestamp)
+	filepath := filepath.Join(outputDir, filename)
+
+	data, err := json.MarshalIndent(fleet, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	if err := os.WriteFile(filepath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write JSON report: %w", err)
+	}
+
+	return filepath, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"ingress-migration-analyzer/internal/models"
 	"ingress-migration-analyzer/pkg/analyze"
+	"ingress-migration-analyzer/pkg/rules"
 )
 
 // MarkdownGenerator generates markdown reports
@@ -56,9 +57,27 @@ func (m *MarkdownGenerator) generateReportContent(analysis *models.ClusterAnalys
 		m.writeHighRiskResources(&content, analysis)
 	}
 
+	// Ingress Controllers
+	m.writeControllers(&content, analysis)
+
+	// Global Controller Settings
+	m.writeGlobalSettings(&content, analysis)
+
+	// Layer 4 Exposure
+	m.writeStreamServices(&content, analysis)
+
+	// DNS Cutover
+	m.writeDNSCutover(&content, analysis)
+
 	// Namespace Analysis
 	m.writeNamespaceAnalysis(&content, analysis)
 
+	// Application Analysis
+	m.writeApplicationAnalysis(&content, analysis)
+
+	// Kustomize Overlay Analysis
+	m.writeOverlayAnalysis(&content, analysis)
+
 	// Detailed Resource Analysis
 	m.writeDetailedAnalysis(&content, analysis)
 
@@ -75,12 +94,62 @@ func (m *MarkdownGenerator) writeHeader(content *strings.Builder, analysis *mode
 	if m.ContextName != "" {
 		content.WriteString(fmt.Sprintf("**Cluster Context**: %s\n", m.ContextName))
 	}
-	content.WriteString(fmt.Sprintf("**Cluster Version**: %s\n", analysis.ScanResult.ClusterVersion))
+	if analysis.ScanResult.Source != "" {
+		content.WriteString(fmt.Sprintf("**Source**: %s\n", analysis.ScanResult.Source))
+	} else {
+		content.WriteString(fmt.Sprintf("**Cluster Version**: %s\n", analysis.ScanResult.ClusterVersion))
+	}
 	content.WriteString(fmt.Sprintf("**Total Ingress Resources**: %d\n", analysis.ScanResult.TotalIngresses))
 	content.WriteString(fmt.Sprintf("**Ingress-NGINX Resources**: %d\n", len(analysis.ScanResult.NginxIngresses)))
+	if filters := analysis.ScanResult.Filters; filters != nil {
+		content.WriteString(fmt.Sprintf("**Scope**: %s\n", m.describeFilters(filters)))
+	}
+
+	m.writeScanErrors(content, analysis.ScanResult.ScanErrors)
 	content.WriteString("\n---\n\n")
 }
 
+// writeScanErrors writes a callout listing the parts of the scan that
+// failed, so an incomplete report cannot be mistaken for a complete one
+func (m *MarkdownGenerator) writeScanErrors(content *strings.Builder, scanErrors []models.ScanError) {
+	if len(scanErrors) == 0 {
+		return
+	}
+
+	content.WriteString(fmt.Sprintf("\n> ⚠️ **Incomplete scan**: %d API errors left parts of the cluster unscanned; the findings below do not cover them.\n>\n", len(scanErrors)))
+	for _, scanError := range scanErrors {
+		scope := string(scanError.Stage)
+		if scanError.Namespace != "" {
+			scope += " in `" + scanError.Namespace + "`"
+		}
+		content.WriteString(fmt.Sprintf("> - **%s**: %s (%s)\n", scope, scanError.Impact, scanError.Error))
+	}
+}
+
+// describeFilters describes the subset of Ingresses a filtered scan covers
+func (m *MarkdownGenerator) describeFilters(filters *models.ScanFilters) string {
+	var parts []string
+	if filters.Namespace != "" {
+		parts = append(parts, fmt.Sprintf("namespace `%s`", filters.Namespace))
+	}
+	if len(filters.IncludeNamespaces) > 0 {
+		parts = append(parts, fmt.Sprintf("namespaces matching `%s`", strings.Join(filters.IncludeNamespaces, "`, `")))
+	}
+	if len(filters.ExcludeNamespaces) > 0 {
+		parts = append(parts, fmt.Sprintf("excluding namespaces `%s`", strings.Join(filters.ExcludeNamespaces, "`, `")))
+	}
+	if filters.NamespaceSelector != "" {
+		parts = append(parts, fmt.Sprintf("namespaces labeled `%s`", filters.NamespaceSelector))
+	}
+	if filters.Selector != "" {
+		parts = append(parts, fmt.Sprintf("Ingresses labeled `%s`", filters.Selector))
+	}
+	if len(filters.Names) > 0 {
+		parts = append(parts, fmt.Sprintf("Ingresses named `%s`", strings.Join(filters.Names, "`, `")))
+	}
+	return strings.Join(parts, "; ")
+}
+
 // writeExecutiveSummary writes the executive summary
 func (m *MarkdownGenerator) writeExecutiveSummary(content *strings.Builder, analysis *models.ClusterAnalysis) {
 	summary := analysis.Summary
@@ -153,6 +222,185 @@ func (m *MarkdownGenerator) writeHighRiskResources(content *strings.Builder, ana
 	content.WriteString("---\n\n")
 }
 
+// writeControllers writes the inventory of ingress-nginx controllers
+func (m *MarkdownGenerator) writeControllers(content *strings.Builder, analysis *models.ClusterAnalysis) {
+	if len(analysis.Controllers) == 0 {
+		return
+	}
+
+	content.WriteString("## Ingress Controllers\n\n")
+	content.WriteString("| Controller | Kind | Version | Replicas | Ingress Class | Controller Class | Watch Namespace | Annotation Prefix | SSL Passthrough | Service | Ingresses |\n")
+	content.WriteString("|------------|------|---------|----------|---------------|------------------|-----------------|-------------------|-----------------|---------|-----------|\n")
+	for _, controller := range analysis.Controllers {
+		watchNamespace := controller.WatchNamespace
+		if watchNamespace == "" {
+			watchNamespace = "all"
+		}
+		service := "-"
+		if controller.Service != "" {
+			service = fmt.Sprintf("%s (%s)", controller.Service, controller.ServiceType)
+		}
+		passthrough := "no"
+		if controller.SSLPassthrough {
+			passthrough = "yes"
+		}
+		content.WriteString(fmt.Sprintf("| %s/%s | %s | %s | %d/%d | %s | `%s` | %s | `%s` | %s | %s | %d |\n",
+			controller.Namespace, controller.Name, controller.Kind, controller.Version,
+			controller.ReadyReplicas, controller.Replicas, controller.IngressClass, controller.ControllerClass,
+			watchNamespace, controller.AnnotationPrefix, passthrough, service, controller.IngressCount))
+	}
+	content.WriteString("\n")
+
+	var unserved int
+	for _, a := range analysis.Analyses {
+		if len(a.Resource.ServedBy) == 0 {
+			unserved++
+		}
+	}
+	if unserved > 0 {
+		content.WriteString(fmt.Sprintf("⚠️ %d ingress-nginx resources are not served by any controller found in the cluster.\n\n", unserved))
+	}
+}
+
+// writeGlobalSettings writes the analysis of each controller ConfigMap
+func (m *MarkdownGenerator) writeGlobalSettings(content *strings.Builder, analysis *models.ClusterAnalysis) {
+	if len(analysis.GlobalSettings) == 0 {
+		return
+	}
+
+	content.WriteString("## Global Controller Settings\n\n")
+	content.WriteString("Settings in the controller ConfigMap apply to every Ingress served by that controller.\n\n")
+
+	for _, settings := range analysis.GlobalSettings {
+		controller := settings.Controller
+		icon := analyze.GetRiskLevelIcon(settings.RiskLevel)
+		content.WriteString(fmt.Sprintf("### %s %s/%s\n\n", icon, controller.Namespace, controller.Name))
+
+		if controller.ConfigMap == "" {
+			content.WriteString("- **ConfigMap**: none (`--configmap` not set, controller defaults apply)\n")
+		} else {
+			content.WriteString(fmt.Sprintf("- **ConfigMap**: `%s`\n", controller.ConfigMap))
+		}
+		if controller.Error != "" {
+			content.WriteString(fmt.Sprintf("- **Error**: %s\n\n", controller.Error))
+			continue
+		}
+		content.WriteString(fmt.Sprintf("- **Risk Level**: %s\n", settings.RiskLevel))
+
+		if len(settings.MatchedRules) > 0 {
+			content.WriteString("- **Settings**:\n")
+			for _, riskLevel := range []models.RiskLevel{models.RiskHigh, models.RiskManual, models.RiskAuto} {
+				for _, rule := range m.getRulesByRisk(settings.MatchedRules, riskLevel) {
+					value := fmt.Sprintf("`%s`", m.truncateValue(controller.Data[rule.Pattern]))
+					if _, set := controller.Data[rule.Pattern]; !set && rules.IsDefaultOn(rule.Pattern) {
+						value = "on by default"
+					}
+					content.WriteString(fmt.Sprintf("  - %s %s: %s → %s",
+						analyze.GetRiskLevelIcon(rule.RiskLevel), rule.Name, value, rule.MigrationNote))
+					if rule.SourceURL != "" {
+						content.WriteString(fmt.Sprintf(" ([docs](%s))", rule.SourceURL))
+					}
+					content.WriteString("\n")
+				}
+			}
+		}
+
+		if len(settings.UnknownKeys) > 0 {
+			content.WriteString(fmt.Sprintf("- **Other Settings**: %s\n", strings.Join(settings.UnknownKeys, ", ")))
+		}
+		content.WriteString("\n")
+	}
+}
+
+// writeStreamServices writes TCP/UDP ports exposed through the tcp-services
+// and udp-services ConfigMaps
+func (m *MarkdownGenerator) writeStreamServices(content *strings.Builder, analysis *models.ClusterAnalysis) {
+	if len(analysis.StreamServices) == 0 {
+		return
+	}
+
+	content.WriteString("## Layer 4 Exposure (TCP/UDP)\n\n")
+	content.WriteString("These ports bypass Ingress resources entirely and are exposed by the controller through ")
+	content.WriteString("`--tcp-services-configmap` / `--udp-services-configmap`. Each one needs a Gateway listener ")
+	content.WriteString("and a TCPRoute or UDPRoute.\n\n")
+
+	content.WriteString("| Port | Protocol | Backend | Target Port | Route | Controller | Risk |\n")
+	content.WriteString("|------|----------|---------|-------------|-------|------------|------|\n")
+	for _, item := range analysis.StreamServices {
+		service := item.Service
+		backend := fmt.Sprintf("%s/%s:%s", service.Namespace, service.Service, service.ServicePort)
+		if service.Service == "" {
+			backend = "-"
+		}
+		if service.ProxyProtocol != "" {
+			backend += fmt.Sprintf(" (PROXY %s)", service.ProxyProtocol)
+		}
+		port, targetPort := service.Port, service.TargetPort
+		if port == "" {
+			port = "-"
+		}
+		if targetPort == "" {
+			targetPort = "-"
+		}
+		content.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s %s |\n",
+			port, service.Protocol, backend, targetPort, item.RouteKind, item.Controller,
+			analyze.GetRiskLevelIcon(item.RiskLevel), item.RiskLevel))
+	}
+	content.WriteString("\n")
+
+	for _, item := range analysis.StreamServices {
+		if item.Service.Error != "" || item.Service.ProxyProtocol != "" {
+			content.WriteString(fmt.Sprintf("- **%s/%s** (`%s`): %s\n",
+				item.Service.Protocol, item.Service.Port, item.Service.ConfigMap, item.MigrationNote))
+		}
+	}
+	content.WriteString("\n")
+}
+
+// writeDNSCutover writes the hosts grouped by the load balancer address
+// their Ingresses report, so DNS owners know which records move together
+func (m *MarkdownGenerator) writeDNSCutover(content *strings.Builder, analysis *models.ClusterAnalysis) {
+	if len(analysis.DNSCutover) == 0 {
+		return
+	}
+
+	content.WriteString("## DNS Cutover\n\n")
+	content.WriteString("Traffic for these hosts enters the cluster at the addresses below, taken from each Ingress's ")
+	content.WriteString("`status.loadBalancer`. When traffic shifts to a Gateway, the DNS records of each group move ")
+	content.WriteString("from this address to the Gateway's address.\n\n")
+
+	content.WriteString("| Address | Hosts | Ingresses |\n")
+	content.WriteString("|---------|-------|-----------|\n")
+	for _, group := range analysis.DNSCutover {
+		address := fmt.Sprintf("`%s`", group.Address)
+		if group.Address == "" {
+			address = "⚠️ no address"
+		}
+		hosts := "`*` (any host)"
+		if len(group.Hosts) > 0 {
+			hosts = strings.Join(group.Hosts, ", ")
+		}
+		content.WriteString(fmt.Sprintf("| %s | %s | %s |\n", address, hosts, strings.Join(group.Ingresses, ", ")))
+	}
+	content.WriteString("\n")
+
+	if last := analysis.DNSCutover[len(analysis.DNSCutover)-1]; last.Address == "" {
+		content.WriteString(fmt.Sprintf("%d Ingresses have no address in their status: the controller has not admitted them, ", len(last.Ingresses)))
+		content.WriteString("or it does not publish a Service address (`--publish-service`). Confirm where their traffic enters before the cutover.\n\n")
+	}
+}
+
+// truncateValue shortens multi-line values such as snippets for inline display
+func (m *MarkdownGenerator) truncateValue(value string) string {
+	if line, _, multiline := strings.Cut(value, "\n"); multiline {
+		value = line + " …"
+	}
+	if runes := []rune(value); len(runes) > 80 {
+		value = string(runes[:80]) + "…"
+	}
+	return value
+}
+
 // writeNamespaceAnalysis creates the namespace breakdown table
 func (m *MarkdownGenerator) writeNamespaceAnalysis(content *strings.Builder, analysis *models.ClusterAnalysis) {
 	if len(analysis.Summary.ByNamespace) <= 1 {
@@ -180,6 +428,121 @@ func (m *MarkdownGenerator) writeNamespaceAnalysis(content *strings.Builder, ana
 	content.WriteString("\n---\n\n")
 }
 
+// writeApplicationAnalysis groups the Ingresses by owning application, so
+// migration work can be assigned to app teams rather than namespaces
+func (m *MarkdownGenerator) writeApplicationAnalysis(content *strings.Builder, analysis *models.ClusterAnalysis) {
+	type applicationRow struct {
+		application models.Application
+		summary     models.NamespaceSummary
+		ingresses   []string
+	}
+	rows := make(map[models.Application]*applicationRow)
+	var attributed bool
+	for _, a := range analysis.Analyses {
+		var application models.Application
+		if a.Application != nil {
+			application = *a.Application
+			attributed = true
+		}
+		row, exists := rows[application]
+		if !exists {
+			row = &applicationRow{application: application}
+			rows[application] = row
+		}
+		switch a.RiskLevel {
+		case models.RiskAuto:
+			row.summary.AutoCount++
+		case models.RiskManual:
+			row.summary.ManualCount++
+		case models.RiskHigh:
+			row.summary.HighRiskCount++
+		}
+		row.ingresses = append(row.ingresses, a.Resource.Namespace+"/"+a.Resource.Name)
+	}
+
+	if !attributed {
+		return // Nothing identifies applications
+	}
+
+	var sorted []*applicationRow
+	for _, row := range rows {
+		sorted = append(sorted, row)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i].application, sorted[j].application
+		if (a.Name == "") != (b.Name == "") {
+			return b.Name == "" // unattributed last
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Source < b.Source
+	})
+
+	content.WriteString("## Analysis by Application\n\n")
+	content.WriteString("Ingresses are attributed to the application that deploys them, from Argo CD, Flux and Helm metadata, ")
+	content.WriteString("the `app.kubernetes.io/part-of` label or their owner references.\n\n")
+	content.WriteString("| Application | Identified By | AUTO | MANUAL | HIGH RISK | Ingresses |\n")
+	content.WriteString("|-------------|---------------|------|--------|-----------|-----------|\n")
+	for _, row := range sorted {
+		name, source := fmt.Sprintf("`%s`", row.application.Name), row.application.Source
+		if row.application.Name == "" {
+			name, source = "⚠️ unattributed", "-"
+		}
+		sort.Strings(row.ingresses)
+		content.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %d | %s |\n",
+			name, source, row.summary.AutoCount, row.summary.ManualCount, row.summary.HighRiskCount,
+			strings.Join(row.ingresses, ", ")))
+	}
+
+	content.WriteString("\n---\n\n")
+}
+
+// writeOverlayAnalysis creates the per-overlay breakdown table, so a risk
+// introduced by an overlay patch stands out against its base
+func (m *MarkdownGenerator) writeOverlayAnalysis(content *strings.Builder, analysis *models.ClusterAnalysis) {
+	byOverlay := make(map[string]models.NamespaceSummary)
+	for _, a := range analysis.Analyses {
+		if a.Resource.Origin == nil || a.Resource.Origin.Overlay == "" {
+			continue
+		}
+		overlay := a.Resource.Origin.Overlay
+		summary := byOverlay[overlay]
+		switch a.RiskLevel {
+		case models.RiskAuto:
+			summary.AutoCount++
+		case models.RiskManual:
+			summary.ManualCount++
+		case models.RiskHigh:
+			summary.HighRiskCount++
+		}
+		byOverlay[overlay] = summary
+	}
+
+	if len(byOverlay) <= 1 {
+		return // Nothing to compare
+	}
+
+	content.WriteString("## Analysis by Kustomize Overlay\n\n")
+	content.WriteString("| Overlay | AUTO | MANUAL | HIGH RISK | Total |\n")
+	content.WriteString("|---------|------|--------|-----------|-------|\n")
+
+	var overlays []string
+	for overlay := range byOverlay {
+		overlays = append(overlays, overlay)
+	}
+	sort.Strings(overlays)
+
+	for _, overlay := range overlays {
+		summary := byOverlay[overlay]
+		total := summary.AutoCount + summary.ManualCount + summary.HighRiskCount
+		content.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d |\n",
+			overlay, summary.AutoCount, summary.ManualCount, summary.HighRiskCount, total))
+	}
+
+	content.WriteString("\n---\n\n")
+}
+
 // writeDetailedAnalysis writes detailed analysis for each resource
 func (m *MarkdownGenerator) writeDetailedAnalysis(content *strings.Builder, analysis *models.ClusterAnalysis) {
 	content.WriteString("## Detailed Resource Analysis\n\n")
@@ -207,10 +570,19 @@ func (m *MarkdownGenerator) writeResourceDetails(content *strings.Builder, analy
 	content.WriteString(fmt.Sprintf("### %s %s/%s\n\n", icon, resource.Namespace, resource.Name))
 	content.WriteString(fmt.Sprintf("- **Risk Level**: %s\n", analysis.RiskLevel))
 	content.WriteString(fmt.Sprintf("- **Ingress Class**: %s\n", resource.ClassName))
-	
-	if len(resource.Hosts) > 0 {
-		content.WriteString(fmt.Sprintf("- **Hosts**: %s\n", strings.Join(resource.Hosts, ", ")))
+	m.writeClassResolution(content, resource)
+	if len(resource.ServedBy) > 0 {
+		content.WriteString(fmt.Sprintf("- **Served By**: %s\n", strings.Join(resource.ServedBy, ", ")))
+	}
+	if resource.AnnotationPrefix != "" && resource.AnnotationPrefix != rules.DefaultAnnotationPrefix {
+		content.WriteString(fmt.Sprintf("- **Annotation Prefix**: `%s`\n", resource.AnnotationPrefix))
 	}
+	m.writeOrigin(content, resource.Origin)
+	m.writeProvenance(content, analysis)
+	
+	m.writeRouting(content, resource)
+	m.writeBackends(content, resource.Backends)
+	m.writeTLS(content, resource)
 
 	// Annotations analysis
 	if len(analysis.MatchedRules) > 0 {
@@ -222,32 +594,38 @@ func (m *MarkdownGenerator) writeResourceDetails(content *strings.Builder, analy
 		highRiskRules := m.getRulesByRisk(analysis.MatchedRules, models.RiskHigh)
 
 		for _, rule := range autoRules {
-			annotationValue := resource.Annotations[rule.Pattern]
-			content.WriteString(fmt.Sprintf("  - ✅ %s: `%s` → %s", 
-				rule.Name, annotationValue, rule.MigrationNote))
+			content.WriteString(fmt.Sprintf("  - ✅ %s: `%s`%s → %s", 
+				m.annotationLabel(rule, resource.AnnotationPrefix), resource.Annotations[rule.Key], m.annotationSource(resource, rule.Key), rule.MigrationNote))
 			if rule.SourceURL != "" {
 				content.WriteString(fmt.Sprintf(" ([docs](%s))", rule.SourceURL))
 			}
+			if rule.Source != "" {
+				content.WriteString(fmt.Sprintf(" _(rule from `%s`)_", rule.Source))
+			}
 			content.WriteString("\n")
 		}
 		
 		for _, rule := range manualRules {
-			annotationValue := resource.Annotations[rule.Pattern]
-			content.WriteString(fmt.Sprintf("  - ⚠️  %s: `%s` → %s", 
-				rule.Name, annotationValue, rule.MigrationNote))
+			content.WriteString(fmt.Sprintf("  - ⚠️  %s: `%s`%s → %s", 
+				m.annotationLabel(rule, resource.AnnotationPrefix), resource.Annotations[rule.Key], m.annotationSource(resource, rule.Key), rule.MigrationNote))
 			if rule.SourceURL != "" {
 				content.WriteString(fmt.Sprintf(" ([docs](%s))", rule.SourceURL))
 			}
+			if rule.Source != "" {
+				content.WriteString(fmt.Sprintf(" _(rule from `%s`)_", rule.Source))
+			}
 			content.WriteString("\n")
 		}
 		
 		for _, rule := range highRiskRules {
-			annotationValue := resource.Annotations[rule.Pattern]
-			content.WriteString(fmt.Sprintf("  - ❌ %s: `%s` → %s", 
-				rule.Name, annotationValue, rule.MigrationNote))
+			content.WriteString(fmt.Sprintf("  - ❌ %s: `%s`%s → %s", 
+				m.annotationLabel(rule, resource.AnnotationPrefix), resource.Annotations[rule.Key], m.annotationSource(resource, rule.Key), rule.MigrationNote))
 			if rule.SourceURL != "" {
 				content.WriteString(fmt.Sprintf(" ([docs](%s))", rule.SourceURL))
 			}
+			if rule.Source != "" {
+				content.WriteString(fmt.Sprintf(" _(rule from `%s`)_", rule.Source))
+			}
 			content.WriteString("\n")
 		}
 	}
@@ -323,6 +701,223 @@ func (m *MarkdownGenerator) writeMigrationRecommendations(content *strings.Build
 	content.WriteString("*Generated by [Ingress-NGINX Migration Analyzer](https://github.com/user/ingress-migration-analyzer)*\n")
 }
 
+// writeClassResolution writes the controller class an Ingress resolved to and why
+func (m *MarkdownGenerator) writeClassResolution(content *strings.Builder, resource models.IngressResource) {
+	if resource.ClassResolution == "" {
+		return
+	}
+
+	class := resource.ResolvedClass
+	if class == "" {
+		class = "unresolved"
+	}
+	if resource.Controller != "" {
+		class = fmt.Sprintf("%s (`%s`)", class, resource.Controller)
+	}
+	content.WriteString(fmt.Sprintf("- **Controller Class**: %s — %s\n", class, resource.ClassResolution))
+}
+
+// writeRouting writes the routing table of a resource: one row per host and
+// path, then the default backend
+func (m *MarkdownGenerator) writeRouting(content *strings.Builder, resource models.IngressResource) {
+	if len(resource.Rules) == 0 && resource.DefaultBackend == nil {
+		return
+	}
+
+	content.WriteString("- **Routing**:\n\n")
+	content.WriteString("  | Host | Path | Path Type | Backend |\n")
+	content.WriteString("  |------|------|-----------|---------|\n")
+	for _, rule := range resource.Rules {
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		if len(rule.Paths) == 0 {
+			content.WriteString(fmt.Sprintf("  | `%s` | - | - | default backend |\n", host))
+			continue
+		}
+		for _, path := range rule.Paths {
+			p := path.Path
+			if p == "" {
+				p = "*"
+			}
+			pathType := path.PathType
+			if pathType == "" {
+				pathType = "-"
+			}
+			content.WriteString(fmt.Sprintf("  | `%s` | `%s` | %s | %s |\n", host, p, pathType, describeBackendRef(path.Backend)))
+		}
+	}
+	if resource.DefaultBackend != nil {
+		content.WriteString(fmt.Sprintf("  | `*` | `*` | - | %s (default backend) |\n", describeBackendRef(*resource.DefaultBackend)))
+	}
+	content.WriteString("\n")
+}
+
+// describeBackendRef formats a backend as `service:port` or `Kind/name`
+func describeBackendRef(ref models.BackendRef) string {
+	switch {
+	case ref.Service != "":
+		return fmt.Sprintf("`%s:%s`", ref.Service, ref.Port)
+	case ref.Resource != "":
+		return fmt.Sprintf("`%s`", ref.Resource)
+	}
+	return "-"
+}
+
+// writeBackends writes the backends of a resource and what they resolved to
+func (m *MarkdownGenerator) writeBackends(content *strings.Builder, backends []models.Backend) {
+	if len(backends) == 0 {
+		return
+	}
+
+	content.WriteString("- **Backends**:\n")
+	for _, backend := range backends {
+		name := backend.Resource
+		if backend.Service != "" {
+			name = fmt.Sprintf("%s:%s", backend.Service, backend.Port)
+		}
+		if backend.Default {
+			name += " (default backend)"
+		}
+
+		icon := "✅"
+		if len(backend.Issues) > 0 {
+			icon = "⚠️"
+		}
+		if !backend.Resolved && len(backend.Issues) == 0 {
+			content.WriteString(fmt.Sprintf("  - `%s`\n", name))
+			continue
+		}
+
+		var details []string
+		if backend.ServiceType != "" {
+			details = append(details, backend.ServiceType)
+		}
+		if backend.PortNumber != 0 {
+			details = append(details, fmt.Sprintf("port %d → %s", backend.PortNumber, backend.TargetPort))
+			details = append(details, fmt.Sprintf("%d ready endpoints", backend.ReadyEndpoints))
+		}
+		line := fmt.Sprintf("  - %s `%s`", icon, name)
+		if len(details) > 0 {
+			line += " — " + strings.Join(details, ", ")
+		}
+		content.WriteString(line + "\n")
+		for _, issue := range backend.Issues {
+			content.WriteString(fmt.Sprintf("    - %s\n", issue))
+		}
+	}
+}
+
+// writeTLS writes the TLS entries of a resource, their certificates and
+// the cert-manager annotations that manage them
+func (m *MarkdownGenerator) writeTLS(content *strings.Builder, resource models.IngressResource) {
+	if len(resource.TLS) == 0 {
+		return
+	}
+
+	content.WriteString("- **TLS**:\n")
+	for _, tls := range resource.TLS {
+		secret := "_default certificate_"
+		if tls.SecretName != "" {
+			secret = fmt.Sprintf("Secret `%s`", tls.SecretName)
+		}
+		icon := "✅"
+		if len(tls.Issues) > 0 {
+			icon = "⚠️"
+		}
+		line := fmt.Sprintf("  - %s %s → %s", icon, strings.Join(tls.Hosts, ", "), secret)
+		if cert := tls.Certificate; cert != nil {
+			line += fmt.Sprintf(" (expires %s, SANs: %s)", cert.NotAfter.Format("2006-01-02"), strings.Join(cert.DNSNames, ", "))
+		}
+		content.WriteString(line + "\n")
+		for _, issue := range tls.Issues {
+			content.WriteString(fmt.Sprintf("    - %s\n", issue))
+		}
+	}
+
+	if keys := analyze.CertManagerAnnotations(resource.Annotations); len(keys) > 0 {
+		var values []string
+		for _, key := range keys {
+			values = append(values, fmt.Sprintf("`%s: %s`", key, resource.Annotations[key]))
+		}
+		content.WriteString(fmt.Sprintf("- **cert-manager**: %s\n", strings.Join(values, ", ")))
+	}
+}
+
+// writeProvenance writes the owning application of a resource and the
+// tools and objects that manage it
+func (m *MarkdownGenerator) writeProvenance(content *strings.Builder, analysis models.IngressAnalysis) {
+	if analysis.Application != nil {
+		content.WriteString(fmt.Sprintf("- **Application**: `%s` (%s)\n", analysis.Application.Name, analysis.Application.Source))
+	}
+
+	provenance := analysis.Resource.Provenance
+	if provenance == nil {
+		return
+	}
+	var parts []string
+	if provenance.ArgoCDApplication != "" {
+		parts = append(parts, fmt.Sprintf("Argo CD Application `%s`", provenance.ArgoCDApplication))
+	}
+	if provenance.FluxKustomization != "" {
+		parts = append(parts, fmt.Sprintf("Flux Kustomization `%s`", provenance.FluxKustomization))
+	}
+	if provenance.FluxHelmRelease != "" {
+		parts = append(parts, fmt.Sprintf("Flux HelmRelease `%s`", provenance.FluxHelmRelease))
+	}
+	if provenance.HelmRelease != "" {
+		parts = append(parts, fmt.Sprintf("Helm release `%s`", provenance.HelmRelease))
+	} else if provenance.ManagedBy != "" {
+		parts = append(parts, fmt.Sprintf("managed by `%s`", provenance.ManagedBy))
+	}
+	for _, owner := range provenance.Owners {
+		parts = append(parts, fmt.Sprintf("owned by `%s`", owner))
+	}
+	if len(parts) > 0 {
+		content.WriteString(fmt.Sprintf("- **Managed By**: %s\n", strings.Join(parts, ", ")))
+	}
+}
+
+// writeOrigin writes where an offline-analyzed resource was loaded from
+func (m *MarkdownGenerator) writeOrigin(content *strings.Builder, origin *models.ResourceOrigin) {
+	if origin == nil {
+		return
+	}
+	if origin.File != "" {
+		content.WriteString(fmt.Sprintf("- **Manifest**: `%s`\n", origin.File))
+	}
+	if origin.Chart != "" {
+		content.WriteString(fmt.Sprintf("- **Helm Chart**: `%s` (template `%s`)\n", origin.Chart, origin.Template))
+		if len(origin.ValuesFiles) > 0 {
+			content.WriteString(fmt.Sprintf("- **Values Files**: `%s`\n", strings.Join(origin.ValuesFiles, "`, `")))
+		}
+	}
+	if origin.Overlay != "" {
+		content.WriteString(fmt.Sprintf("- **Kustomize Overlay**: `%s`\n", origin.Overlay))
+	}
+}
+
+// annotationLabel names a matched annotation rule, adding the annotation key
+// when the rule is a regexp that may match several keys
+func (m *MarkdownGenerator) annotationLabel(rule models.AnnotationRule, prefix string) string {
+	if rules.AnnotationKeyWithPrefix(rule.Pattern, prefix) == rule.Key {
+		return rule.Name
+	}
+	return fmt.Sprintf("%s (`%s`)", rule.Name, rule.Key)
+}
+
+// annotationSource returns a note naming the values file that set an annotation
+func (m *MarkdownGenerator) annotationSource(resource models.IngressResource, key string) string {
+	if resource.Origin == nil {
+		return ""
+	}
+	if source, ok := resource.Origin.AnnotationSources[key]; ok {
+		return fmt.Sprintf(" _(set in `%s`)_", source)
+	}
+	return ""
+}
+
 // Helper functions
 
 func (m *MarkdownGenerator) getRulesByRisk(rules []models.AnnotationRule, riskLevel models.RiskLevel) []models.AnnotationRule {
@@ -0,0 +1,60 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ingress-migration-analyzer/internal/models"
+	"ingress-migration-analyzer/pkg/rules"
+)
+
+func TestResourceDetailsShowAnnotationsMatchedByRegexpRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	ruleFile := `
+rules:
+- pattern: nginx.ingress.kubernetes.io/x-team-.*
+  name: Team
+  riskLevel: MANUAL
+  migrationNote: Use the team ExtensionRef.
+`
+	if err := os.WriteFile(path, []byte(ruleFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := rules.LoadRuleFiles([]string{path}); err != nil {
+		t.Fatalf("LoadRuleFiles() error = %v", err)
+	}
+	t.Cleanup(func() { rules.LoadRuleFiles(nil) })
+
+	resource := models.IngressResource{
+		Namespace: "shop",
+		Name:      "web",
+		Annotations: map[string]string{
+			"nginx.ingress.kubernetes.io/x-team-region": "eu",
+			"nginx.ingress.kubernetes.io/x-team-owner":  "payments",
+			"nginx.ingress.kubernetes.io/ssl-redirect":  "true",
+		},
+		Origin: &models.ResourceOrigin{
+			Chart:             "chart",
+			AnnotationSources: map[string]string{"nginx.ingress.kubernetes.io/x-team-owner": "values-prod.yaml (team.owner)"},
+		},
+	}
+	analysis := models.IngressAnalysis{
+		Resource:     resource,
+		MatchedRules: rules.MatchAnnotations(resource.Annotations),
+		RiskLevel:    models.RiskManual,
+	}
+
+	var content strings.Builder
+	NewMarkdownGenerator().writeResourceDetails(&content, analysis)
+	for _, want := range []string{
+		"Team (`nginx.ingress.kubernetes.io/x-team-owner`): `payments` _(set in `values-prod.yaml (team.owner)`)_ → Use the team ExtensionRef.",
+		"Team (`nginx.ingress.kubernetes.io/x-team-region`): `eu` → Use the team ExtensionRef.",
+		"SSL Redirect: `true` →",
+	} {
+		if !strings.Contains(content.String(), want) {
+			t.Errorf("resource details missing %q:\n%s", want, content.String())
+		}
+	}
+}
@@ -2,14 +2,31 @@ package rules
 
 import (
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	"ingress-migration-analyzer/internal/models"
 )
 
-// GetAnnotationRules returns the complete set of annotation classification rules
+// DefaultAnnotationPrefix is the ingress-nginx annotation prefix used by
+// rule patterns. Controllers may override it with --annotation-prefix.
+const DefaultAnnotationPrefix = "nginx.ingress.kubernetes.io"
+
+// GetAnnotationRules returns the complete set of annotation classification
+// rules: the built-in rules with any rule files loaded by LoadRuleFiles applied
 func GetAnnotationRules() []models.AnnotationRule {
-	return []models.AnnotationRule{
+	if activeRules != nil {
+		return activeRules
+	}
+	return builtinAnnotationRules()
+}
+
+// builtinAnnotationRules returns the annotation classification rules shipped
+// with the analyzer: the core rules below and the catalog of the remaining
+// documented ingress-nginx annotations
+func builtinAnnotationRules() []models.AnnotationRule {
+	rules := []models.AnnotationRule{
 		// Tier A - AUTO (annotations with established Gateway API equivalents)
 		{
 			Name:        "Rewrite Target",
@@ -19,6 +36,14 @@ func GetAnnotationRules() []models.AnnotationRule {
 			MigrationNote: "Gateway API HTTPRoute supports path rewriting via URLRewrite filters (GEP-726). " +
 				"Most Gateway implementations support this feature.",
 			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-redirect-rewrite/",
+			Conditions: []models.ValueCondition{
+				{
+					Value:     `.*\$[0-9].*`,
+					RiskLevel: models.RiskManual,
+					MigrationNote: "The target references regex capture groups. URLRewrite only replaces a full path " +
+						"or a prefix, so this needs RegularExpression path matches and an implementation-specific regex rewrite.",
+				},
+			},
 		},
 		{
 			Name:        "SSL Redirect",
@@ -28,6 +53,14 @@ func GetAnnotationRules() []models.AnnotationRule {
 			MigrationNote: "Gateway API HTTPRoute supports HTTPS redirects via RequestRedirect filters. " +
 				"Standard feature across Gateway implementations.",
 			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-redirect-rewrite/",
+			Conditions: []models.ValueCondition{
+				{
+					Value:     "false",
+					RiskLevel: models.RiskAuto,
+					MigrationNote: "The redirect is disabled: add no RequestRedirect filter and attach the HTTPRoute " +
+						"to the HTTP listener as well.",
+				},
+			},
 		},
 		{
 			Name:        "Force SSL Redirect",
@@ -37,6 +70,9 @@ func GetAnnotationRules() []models.AnnotationRule {
 			MigrationNote: "Gateway API HTTPRoute supports HTTPS redirects via RequestRedirect filters. " +
 				"Similar implementation pattern to ssl-redirect.",
 			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-redirect-rewrite/",
+			Conditions: []models.ValueCondition{
+				{Value: "false", RiskLevel: models.RiskAuto, MigrationNote: "Default value; nothing to migrate."},
+			},
 		},
 		{
 			Name:        "Backend Protocol",
@@ -46,6 +82,31 @@ func GetAnnotationRules() []models.AnnotationRule {
 			MigrationNote: "Gateway API BackendRef supports protocol fields, but implementation " +
 				"varies by Gateway provider. Verify your Gateway supports the required protocols.",
 			SourceURL: "https://gateway-api.sigs.k8s.io/reference/spec/#backendref",
+			Conditions: []models.ValueCondition{
+				{
+					Value:         "HTTP",
+					RiskLevel:     models.RiskAuto,
+					MigrationNote: "HTTP is the default backend protocol; nothing to migrate.",
+				},
+				{
+					Value:     "GRPCS?",
+					RiskLevel: models.RiskManual,
+					MigrationNote: "Route gRPC traffic with a GRPCRoute (standard since v1.1) instead of an HTTPRoute. " +
+						"GRPCS additionally needs a BackendTLSPolicy or appProtocol on the Service port.",
+				},
+				{
+					Value:     "HTTPS",
+					RiskLevel: models.RiskManual,
+					MigrationNote: "Attach a BackendTLSPolicy or set appProtocol https on the Service port, and check " +
+						"whether your Gateway verifies backend certificates that NGINX did not.",
+				},
+				{
+					Value:     "FCGI|AJP",
+					RiskLevel: models.RiskHigh,
+					MigrationNote: "Gateway API only proxies HTTP, HTTP/2 and gRPC to backends. Put an HTTP server in " +
+						"front of the FastCGI or AJP backend before migrating.",
+				},
+			},
 		},
 		{
 			Name:        "Use Regex",
@@ -55,6 +116,13 @@ func GetAnnotationRules() []models.AnnotationRule {
 			MigrationNote: "Gateway API HTTPRoute supports RegularExpression path matching (v1.1+). " +
 				"Verify your Gateway implementation supports regex and review syntax differences.",
 			SourceURL: "https://gateway-api.sigs.k8s.io/reference/spec/#httppathmatch",
+			Conditions: []models.ValueCondition{
+				{
+					Value:         "false",
+					RiskLevel:     models.RiskAuto,
+					MigrationNote: "Regex matching is off; Prefix and Exact path matches are enough.",
+				},
+			},
 		},
 
 		// Tier B - MANUAL (medium complexity, requires review)
@@ -67,6 +135,13 @@ func GetAnnotationRules() []models.AnnotationRule {
 			MigrationNote: "No standardized Gateway API equivalent. Gateway implementations may support " +
 				"request size limits via vendor-specific policies. Check your Gateway documentation.",
 			SourceURL: "https://kubernetes.github.io/ingress-nginx/user-guide/nginx-configuration/annotations/#proxy-body-size",
+			Conditions: []models.ValueCondition{
+				{
+					Value: "0",
+					MigrationNote: "Body size checks are disabled. Make sure the default request size limit of your " +
+						"Gateway does not reject large uploads.",
+				},
+			},
 		},
 		{
 			Name:        "Proxy Read Timeout",
@@ -121,6 +196,9 @@ func GetAnnotationRules() []models.AnnotationRule {
 			MigrationNote: "Some Gateway implementations support CORS via policies. " +
 				"Alternatively, implement CORS at application level or via service mesh.",
 			SourceURL: "https://kubernetes.github.io/ingress-nginx/user-guide/nginx-configuration/annotations/#enable-cors",
+			Conditions: []models.ValueCondition{
+				{Value: "false", RiskLevel: models.RiskAuto, MigrationNote: "CORS is off; nothing to migrate."},
+			},
 		},
 		{
 			Name:        "Rate Limiting",
@@ -180,6 +258,7 @@ func GetAnnotationRules() []models.AnnotationRule {
 			SourceURL: "https://kubernetes.github.io/ingress-nginx/user-guide/nginx-configuration/annotations/#configuration-snippet",
 		},
 	}
+	return append(rules, catalogAnnotationRules()...)
 }
 
 // GetRuleByPattern returns the rule that matches an annotation pattern
@@ -195,39 +274,75 @@ func GetRuleByPattern(annotationKey string) *models.AnnotationRule {
 	return nil
 }
 
+// GetRuleForAnnotation returns the rule matching a canonical annotation key
+// the same way MatchAnnotations does, so rule file patterns that are regexps
+// match too. It returns nil for unknown annotations.
+func GetRuleForAnnotation(canonicalKey string) *models.AnnotationRule {
+	if rule, ok := matchRule(GetAnnotationRules(), canonicalKey); ok {
+		return &rule
+	}
+	return nil
+}
+
+// GetRuleForAnnotationValue returns the rule matching a canonical annotation
+// key with its value conditions evaluated against value, as MatchAnnotations
+// does. It returns nil for unknown annotations.
+func GetRuleForAnnotationValue(canonicalKey, value string) *models.AnnotationRule {
+	if rule, ok := matchRule(GetAnnotationRules(), canonicalKey); ok {
+		rule = applyConditions(rule, value)
+		return &rule
+	}
+	return nil
+}
+
 // MatchAnnotations finds all rules that match the given annotations
 func MatchAnnotations(annotations map[string]string) []models.AnnotationRule {
+	return MatchAnnotationsWithPrefix(annotations, DefaultAnnotationPrefix)
+}
+
+// MatchAnnotationsWithPrefix finds all rules that match the given annotations
+// for a controller running with a custom --annotation-prefix. The value
+// conditions of each rule are evaluated against the annotation value, and
+// each matched rule records the annotation key it matched, in key order.
+func MatchAnnotationsWithPrefix(annotations map[string]string, prefix string) []models.AnnotationRule {
 	var matchedRules []models.AnnotationRule
 	rules := GetAnnotationRules()
 
 	for annotationKey := range annotations {
-		for _, rule := range rules {
-			if matches, _ := regexp.MatchString(rule.Pattern, annotationKey); matches {
-				matchedRules = append(matchedRules, rule)
-				break // Only match each annotation once
-			}
+		canonicalKey, ok := CanonicalAnnotationKey(annotationKey, prefix)
+		if !ok {
+			continue
+		}
+		if rule, ok := matchRule(rules, canonicalKey); ok {
+			matched := applyConditions(rule, annotations[annotationKey])
+			matched.Key = annotationKey
+			matchedRules = append(matchedRules, matched)
 		}
 	}
+	sort.Slice(matchedRules, func(i, j int) bool {
+		return matchedRules[i].Key < matchedRules[j].Key
+	})
 
 	return matchedRules
 }
 
 // GetUnknownNginxAnnotations identifies nginx annotations not in our rules
 func GetUnknownNginxAnnotations(annotations map[string]string) []string {
+	return GetUnknownNginxAnnotationsWithPrefix(annotations, DefaultAnnotationPrefix)
+}
+
+// GetUnknownNginxAnnotationsWithPrefix identifies nginx annotations not in
+// our rules for a controller running with a custom --annotation-prefix. The
+// returned keys use the prefix found on the Ingress.
+func GetUnknownNginxAnnotationsWithPrefix(annotations map[string]string, prefix string) []string {
 	var unknown []string
 	rules := GetAnnotationRules()
 
-	// Create a set of known patterns
-	knownPatterns := make(map[string]bool)
-	for _, rule := range rules {
-		knownPatterns[rule.Pattern] = true
-	}
-
 	for annotationKey := range annotations {
 		// Check if it's an nginx annotation
-		if strings.HasPrefix(annotationKey, "nginx.ingress.kubernetes.io/") {
+		if canonicalKey, ok := CanonicalAnnotationKey(annotationKey, prefix); ok {
 			// Check if we have a rule for it
-			if !knownPatterns[annotationKey] {
+			if _, known := matchRule(rules, canonicalKey); !known {
 				unknown = append(unknown, annotationKey)
 			}
 		}
@@ -236,6 +351,107 @@ func GetUnknownNginxAnnotations(annotations map[string]string) []string {
 	return unknown
 }
 
+// matchRule returns the first rule whose pattern matches the whole
+// canonical annotation key, so that e.g. the canary rule does not match
+// canary-weight
+func matchRule(rules []models.AnnotationRule, canonicalKey string) (models.AnnotationRule, bool) {
+	for _, rule := range rules {
+		if patternMatches(rule.Pattern, canonicalKey) {
+			return rule, true
+		}
+	}
+	return models.AnnotationRule{}, false
+}
+
+// applyConditions returns rule with the risk level and migration note of its
+// first value condition that matches value. The returned rule has no
+// conditions, since they have been evaluated.
+func applyConditions(rule models.AnnotationRule, value string) models.AnnotationRule {
+	conditions := rule.Conditions
+	rule.Conditions = nil
+
+	for _, condition := range conditions {
+		if !valueMatches(condition.Value, value) {
+			continue
+		}
+		if condition.RiskLevel != "" {
+			rule.RiskLevel = condition.RiskLevel
+		}
+		if condition.MigrationNote != "" {
+			rule.MigrationNote = condition.MigrationNote
+		}
+		break
+	}
+
+	return rule
+}
+
+// patternMatches reports whether the regexp pattern matches all of key
+func patternMatches(pattern, key string) bool {
+	return pattern == key || matchesRegexp("^(?:"+pattern+")$", key)
+}
+
+// valueMatches reports whether the regexp of a value condition matches all
+// of value, ignoring case and surrounding whitespace
+func valueMatches(expr, value string) bool {
+	return matchesRegexp("(?i)^(?:"+expr+")$", strings.TrimSpace(value))
+}
+
+// compiledRegexps caches the regexps of rule patterns and value conditions
+var compiledRegexps sync.Map
+
+// matchesRegexp reports whether expr matches s; invalid expressions match
+// nothing
+func matchesRegexp(expr, s string) bool {
+	cached, ok := compiledRegexps.Load(expr)
+	if !ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return false
+		}
+		cached, _ = compiledRegexps.LoadOrStore(expr, re)
+	}
+	return cached.(*regexp.Regexp).MatchString(s)
+}
+
+// CanonicalAnnotationKey rewrites an annotation key using prefix to the
+// default nginx.ingress.kubernetes.io prefix used by rule patterns. It
+// reports false when the key does not use prefix.
+func CanonicalAnnotationKey(key, prefix string) (string, bool) {
+	if prefix == "" {
+		prefix = DefaultAnnotationPrefix
+	}
+	name, found := strings.CutPrefix(key, prefix+"/")
+	if !found {
+		return "", false
+	}
+	return DefaultAnnotationPrefix + "/" + name, true
+}
+
+// AnnotationKeyWithPrefix rewrites a rule pattern using the default prefix to
+// the annotation key used by a controller running with prefix
+func AnnotationKeyWithPrefix(pattern, prefix string) string {
+	if prefix == "" || prefix == DefaultAnnotationPrefix {
+		return pattern
+	}
+	if name, found := strings.CutPrefix(pattern, DefaultAnnotationPrefix+"/"); found {
+		return prefix + "/" + name
+	}
+	return pattern
+}
+
+// riskOrder ranks risk levels from lowest to highest
+var riskOrder = map[models.RiskLevel]int{
+	models.RiskAuto:   0,
+	models.RiskManual: 1,
+	models.RiskHigh:   2,
+}
+
+// HigherRisk reports whether risk level a is higher than b
+func HigherRisk(a, b models.RiskLevel) bool {
+	return riskOrder[a] > riskOrder[b]
+}
+
 // GetHighestRiskLevel determines the highest risk level from a set of rules
 func GetHighestRiskLevel(rules []models.AnnotationRule) models.RiskLevel {
 	if len(rules) == 0 {
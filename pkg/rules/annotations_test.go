@@ -1,6 +1,8 @@
 package rules
 
 import (
+	"os"
+	"strings"
 	"testing"
 
 	"ingress-migration-analyzer/internal/models"
@@ -47,7 +49,7 @@ func TestMatchAnnotations(t *testing.T) {
 		{
 			name: "auto-migratable annotations",
 			annotations: map[string]string{
-				"nginx.ingress.kubernetes.io/rewrite-target": "/api/$1",
+				"nginx.ingress.kubernetes.io/rewrite-target": "/api",
 				"nginx.ingress.kubernetes.io/ssl-redirect":   "true",
 			},
 			wantCount: 2,
@@ -155,4 +157,116 @@ func TestGetRuleByPattern(t *testing.T) {
 	if rule != nil {
 		t.Error("Expected nil for unknown annotation, got rule")
 	}
-}
\ No newline at end of file
+}
+
+func TestMatchAnnotationsWithPrefix(t *testing.T) {
+	annotations := map[string]string{
+		"internal.ingress.example.com/rewrite-target": "/",
+		"internal.ingress.example.com/not-a-real-one": "x",
+		"nginx.ingress.kubernetes.io/server-snippet":  "ignored with a custom prefix",
+	}
+
+	matched := MatchAnnotationsWithPrefix(annotations, "internal.ingress.example.com")
+	if len(matched) != 1 || matched[0].Pattern != "nginx.ingress.kubernetes.io/rewrite-target" {
+		t.Errorf("matched %v, want only rewrite-target", matched)
+	}
+
+	unknown := GetUnknownNginxAnnotationsWithPrefix(annotations, "internal.ingress.example.com")
+	if len(unknown) != 1 || unknown[0] != "internal.ingress.example.com/not-a-real-one" {
+		t.Errorf("unknown = %v, want [internal.ingress.example.com/not-a-real-one]", unknown)
+	}
+
+	key := AnnotationKeyWithPrefix("nginx.ingress.kubernetes.io/rewrite-target", "internal.ingress.example.com")
+	if key != "internal.ingress.example.com/rewrite-target" {
+		t.Errorf("AnnotationKeyWithPrefix() = %s", key)
+	}
+}
+
+func TestBuiltinRulesCoverDocumentedAnnotations(t *testing.T) {
+	data, err := os.ReadFile("testdata/ingress-nginx-annotations.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	documented := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		documented[line] = "value"
+		if GetRuleByPattern(line) == nil {
+			t.Errorf("documented annotation %s has no built-in rule", line)
+		}
+	}
+
+	if unknown := GetUnknownNginxAnnotations(documented); len(unknown) > 0 {
+		t.Errorf("documented annotations reported as unknown: %v", unknown)
+	}
+	if matched := MatchAnnotations(documented); len(matched) != len(documented) {
+		t.Errorf("MatchAnnotations() matched %d of %d documented annotations", len(matched), len(documented))
+	}
+
+	seen := make(map[string]bool)
+	for _, rule := range GetAnnotationRules() {
+		if seen[rule.Pattern] {
+			t.Errorf("duplicate built-in rule for %s", rule.Pattern)
+		}
+		seen[rule.Pattern] = true
+	}
+}
+
+func TestMatchAnnotationsMatchesWholeKey(t *testing.T) {
+	tests := map[string]string{
+		"nginx.ingress.kubernetes.io/force-ssl-redirect": "Force SSL Redirect",
+		"nginx.ingress.kubernetes.io/canary-weight":      "Canary Weight",
+		"nginx.ingress.kubernetes.io/limit-rate-after":   "Limit Rate After",
+		"nginx.ingress.kubernetes.io/proxy-ssl-ciphers":  "Proxy SSL Ciphers",
+	}
+	for key, want := range tests {
+		matched := MatchAnnotations(map[string]string{key: "x"})
+		if len(matched) != 1 || matched[0].Name != want {
+			t.Errorf("MatchAnnotations(%s) = %v, want the %s rule", key, matched, want)
+		}
+	}
+
+	if unknown := GetUnknownNginxAnnotations(map[string]string{"nginx.ingress.kubernetes.io/canary-wieght": "10"}); len(unknown) != 1 {
+		t.Errorf("GetUnknownNginxAnnotations() = %v, want the misspelled key", unknown)
+	}
+}
+
+func TestMatchAnnotationsEvaluatesValueConditions(t *testing.T) {
+	tests := []struct {
+		key, value string
+		wantRisk   models.RiskLevel
+		wantNote   string
+	}{
+		{"backend-protocol", "HTTP", models.RiskAuto, "default backend protocol"},
+		{"backend-protocol", "grpc", models.RiskManual, "GRPCRoute"},
+		{"backend-protocol", "FCGI", models.RiskHigh, "FastCGI"},
+		{"backend-protocol", "AUTO_HTTP", models.RiskManual, "implementation varies"},
+		{"rewrite-target", "/", models.RiskAuto, "URLRewrite filters"},
+		{"rewrite-target", "/$2", models.RiskManual, "capture groups"},
+		{"ssl-redirect", " False ", models.RiskAuto, "redirect is disabled"},
+		{"ssl-redirect", "true", models.RiskAuto, "RequestRedirect filters"},
+		{"proxy-body-size", "0", models.RiskManual, "checks are disabled"},
+		{"enable-modsecurity", "false", models.RiskAuto, "nothing to migrate"},
+		{"enable-modsecurity", "true", models.RiskHigh, "no WAF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			matched := MatchAnnotations(map[string]string{"nginx.ingress.kubernetes.io/" + tt.key: tt.value})
+			if len(matched) != 1 {
+				t.Fatalf("MatchAnnotations() returned %d rules, want 1", len(matched))
+			}
+			if matched[0].RiskLevel != tt.wantRisk || !strings.Contains(matched[0].MigrationNote, tt.wantNote) {
+				t.Errorf("got %s %q, want %s with a note containing %q",
+					matched[0].RiskLevel, matched[0].MigrationNote, tt.wantRisk, tt.wantNote)
+			}
+			if matched[0].Conditions != nil {
+				t.Error("matched rules should not carry their evaluated conditions")
+			}
+		})
+	}
+}
@@ -0,0 +1,1026 @@
+package rules
+
+import (
+	"ingress-migration-analyzer/internal/models"
+)
+
+// nginxAnnotationDocs is the ingress-nginx annotation reference; rules link
+// to the section documenting their annotation
+const nginxAnnotationDocs = "https://kubernetes.github.io/ingress-nginx/user-guide/nginx-configuration/annotations/"
+
+// catalogAnnotationRules returns the rules for the documented ingress-nginx
+// annotations that are not covered by the core rules in annotations.go, so
+// that only typos and custom keys are reported as unknown
+func catalogAnnotationRules() []models.AnnotationRule {
+	return []models.AnnotationRule{
+		// Tier A - AUTO (annotations with established Gateway API equivalents)
+		{
+			Name:        "App Root",
+			Pattern:     "nginx.ingress.kubernetes.io/app-root",
+			RiskLevel:   models.RiskAuto,
+			Description: "Redirects requests for / to the application root path",
+			MigrationNote: "Add an HTTPRoute rule with an Exact match on / and a RequestRedirect filter " +
+				"replacing the path with the app root.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-redirect-rewrite/",
+		},
+		{
+			Name:        "Permanent Redirect",
+			Pattern:     "nginx.ingress.kubernetes.io/permanent-redirect",
+			RiskLevel:   models.RiskAuto,
+			Description: "Redirects every request to a fixed URL with 301",
+			MigrationNote: "Use a RequestRedirect filter with the scheme, hostname and path of the target URL " +
+				"and statusCode 301.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-redirect-rewrite/",
+		},
+		{
+			Name:        "Permanent Redirect Code",
+			Pattern:     "nginx.ingress.kubernetes.io/permanent-redirect-code",
+			RiskLevel:   models.RiskAuto,
+			Description: "Status code used by permanent-redirect",
+			MigrationNote: "Set statusCode on the RequestRedirect filter. 301 and 302 are core support; " +
+				"303, 307 and 308 are extended and need to be checked against your Gateway.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/reference/spec/#httprequestredirectfilter",
+		},
+		{
+			Name:        "Temporal Redirect",
+			Pattern:     "nginx.ingress.kubernetes.io/temporal-redirect",
+			RiskLevel:   models.RiskAuto,
+			Description: "Redirects every request to a fixed URL with 302",
+			MigrationNote: "Use a RequestRedirect filter with the scheme, hostname and path of the target URL " +
+				"and statusCode 302.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-redirect-rewrite/",
+		},
+		{
+			Name:        "Temporal Redirect Code",
+			Pattern:     "nginx.ingress.kubernetes.io/temporal-redirect-code",
+			RiskLevel:   models.RiskAuto,
+			Description: "Status code used by temporal-redirect",
+			MigrationNote: "Set statusCode on the RequestRedirect filter. 301 and 302 are core support; " +
+				"303, 307 and 308 are extended and need to be checked against your Gateway.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/reference/spec/#httprequestredirectfilter",
+		},
+		{
+			Name:        "From-To WWW Redirect",
+			Pattern:     "nginx.ingress.kubernetes.io/from-to-www-redirect",
+			RiskLevel:   models.RiskAuto,
+			Description: "Redirects between www.host and host",
+			MigrationNote: "Add an HTTPRoute for the other hostname with a RequestRedirect filter setting the " +
+				"hostname. The Gateway listener certificate must also cover that hostname.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-redirect-rewrite/",
+		},
+		{
+			Name:        "Server Alias",
+			Pattern:     "nginx.ingress.kubernetes.io/server-alias",
+			RiskLevel:   models.RiskAuto,
+			Description: "Additional hostnames served by the Ingress rules",
+			MigrationNote: "Add the aliases to the hostnames of the HTTPRoute, and to the listener " +
+				"certificate when TLS is used.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/reference/spec/#httproutespec",
+		},
+		{
+			Name:        "X-Forwarded-Prefix",
+			Pattern:     "nginx.ingress.kubernetes.io/x-forwarded-prefix",
+			RiskLevel:   models.RiskAuto,
+			Description: "Adds an X-Forwarded-Prefix header to upstream requests",
+			MigrationNote: "Use a RequestHeaderModifier filter to add the X-Forwarded-Prefix header, " +
+				"usually next to the URLRewrite filter that strips the prefix.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-header-modifier/",
+		},
+		{
+			Name:          "Upstream Vhost",
+			Pattern:       "nginx.ingress.kubernetes.io/upstream-vhost",
+			RiskLevel:     models.RiskAuto,
+			Description:   "Overrides the Host header sent to the backend",
+			MigrationNote: "Use a URLRewrite filter with hostname set to the upstream vhost.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/reference/spec/#httpurlrewritefilter",
+		},
+		{
+			Name:        "Canary",
+			Pattern:     "nginx.ingress.kubernetes.io/canary",
+			RiskLevel:   models.RiskAuto,
+			Description: "Marks the Ingress as a canary of the Ingress with the same host and path",
+			MigrationNote: "Gateway API has no separate canary resources: merge the canary and primary Ingresses " +
+				"into one HTTPRoute whose rules split or match traffic between both backends.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/traffic-splitting/",
+			Conditions: []models.ValueCondition{
+				{Value: "false", MigrationNote: "Not a canary; migrate it as a regular Ingress."},
+			},
+		},
+		{
+			Name:        "Canary Weight",
+			Pattern:     "nginx.ingress.kubernetes.io/canary-weight",
+			RiskLevel:   models.RiskAuto,
+			Description: "Percentage of requests sent to the canary",
+			MigrationNote: "Use weighted backendRefs in the HTTPRoute rule: the canary weight on the canary " +
+				"Service and the remainder on the primary Service.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/traffic-splitting/",
+		},
+		{
+			Name:        "Canary Weight Total",
+			Pattern:     "nginx.ingress.kubernetes.io/canary-weight-total",
+			RiskLevel:   models.RiskAuto,
+			Description: "Total that canary-weight is relative to (default 100)",
+			MigrationNote: "backendRef weights are relative to their sum, so use canary-weight and " +
+				"canary-weight-total minus canary-weight as the two weights.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/traffic-splitting/",
+		},
+		{
+			Name:        "Canary By Header",
+			Pattern:     "nginx.ingress.kubernetes.io/canary-by-header",
+			RiskLevel:   models.RiskAuto,
+			Description: "Header that routes requests to the canary",
+			MigrationNote: "Add an HTTPRoute rule matching the header with value \"always\" to the canary backend. " +
+				"\"never\" needs no rule, since unmatched requests go to the primary backend.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-routing/",
+		},
+		{
+			Name:          "Canary By Header Value",
+			Pattern:       "nginx.ingress.kubernetes.io/canary-by-header-value",
+			RiskLevel:     models.RiskAuto,
+			Description:   "Header value that routes requests to the canary",
+			MigrationNote: "Use an Exact header match on canary-by-header with this value in the canary rule.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/reference/spec/#httpheadermatch",
+		},
+
+		// Tier B - MANUAL (medium complexity, requires review)
+		{
+			Name:        "Canary By Header Pattern",
+			Pattern:     "nginx.ingress.kubernetes.io/canary-by-header-pattern",
+			RiskLevel:   models.RiskManual,
+			Description: "Header value regex that routes requests to the canary",
+			MigrationNote: "RegularExpression header matches are implementation-specific; check your Gateway's " +
+				"regex syntax or replace the pattern with Exact matches.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/reference/spec/#httpheadermatch",
+		},
+		{
+			Name:        "Canary By Cookie",
+			Pattern:     "nginx.ingress.kubernetes.io/canary-by-cookie",
+			RiskLevel:   models.RiskManual,
+			Description: "Cookie that routes requests to the canary",
+			MigrationNote: "HTTPRoute cannot match cookies directly. Use a RegularExpression match on the Cookie " +
+				"header (implementation-specific) or switch clients to a canary header.",
+			SourceURL: nginxAnnotationDocs + "#canary",
+		},
+		{
+			Name:        "Affinity Canary Behavior",
+			Pattern:     "nginx.ingress.kubernetes.io/affinity-canary-behavior",
+			RiskLevel:   models.RiskManual,
+			Description: "Whether sticky sessions keep clients on the canary",
+			MigrationNote: "Depends on session persistence support in your Gateway. Verify how sticky sessions " +
+				"interact with weighted backendRefs before rolling out canaries.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/geps/gep-1619/",
+		},
+		{
+			Name:        "Mirror Target",
+			Pattern:     "nginx.ingress.kubernetes.io/mirror-target",
+			RiskLevel:   models.RiskManual,
+			Description: "URL that receives a copy of every request",
+			MigrationNote: "The RequestMirror filter mirrors to a backendRef, not a URL. Expose the mirror " +
+				"target as a Service (ExternalName for external hosts) and reference it.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-request-mirroring/",
+		},
+		{
+			Name:        "Mirror Request Body",
+			Pattern:     "nginx.ingress.kubernetes.io/mirror-request-body",
+			RiskLevel:   models.RiskManual,
+			Description: "Whether mirrored requests include the body",
+			MigrationNote: "Gateway API does not configure whether bodies are mirrored. Check what your " +
+				"Gateway sends to RequestMirror backends.",
+			SourceURL: nginxAnnotationDocs + "#mirror",
+		},
+		{
+			Name:        "Mirror Host",
+			Pattern:     "nginx.ingress.kubernetes.io/mirror-host",
+			RiskLevel:   models.RiskManual,
+			Description: "Host header of mirrored requests",
+			MigrationNote: "RequestMirror has no Host override. Mirror to a backend that accepts the original " +
+				"Host header.",
+			SourceURL: nginxAnnotationDocs + "#mirror",
+		},
+		{
+			Name:        "Custom HTTP Errors",
+			Pattern:     "nginx.ingress.kubernetes.io/custom-http-errors",
+			RiskLevel:   models.RiskManual,
+			Description: "Upstream status codes answered by the default backend",
+			MigrationNote: "Gateway API does not intercept upstream errors. Use an implementation policy for " +
+				"custom error pages or serve them from the application.",
+			SourceURL: nginxAnnotationDocs + "#custom-http-errors",
+		},
+		{
+			Name:        "Default Backend",
+			Pattern:     "nginx.ingress.kubernetes.io/default-backend",
+			RiskLevel:   models.RiskManual,
+			Description: "Service serving custom errors and requests without endpoints",
+			MigrationNote: "A catch-all HTTPRoute rule covers unmatched paths, but serving errors from another " +
+				"Service needs implementation support.",
+			SourceURL: nginxAnnotationDocs + "#default-backend",
+		},
+		{
+			Name:          "Disable Proxy Intercept Errors",
+			Pattern:       "nginx.ingress.kubernetes.io/disable-proxy-intercept-errors",
+			RiskLevel:     models.RiskManual,
+			Description:   "Passes upstream error responses through despite custom-http-errors",
+			MigrationNote: "Only meaningful together with custom-http-errors; review it with the error page setup.",
+			SourceURL:     nginxAnnotationDocs + "#disable-proxy-intercept-errors",
+		},
+		{
+			Name:        "Custom Headers",
+			Pattern:     "nginx.ingress.kubernetes.io/custom-headers",
+			RiskLevel:   models.RiskManual,
+			Description: "ConfigMap of headers added to responses",
+			MigrationNote: "Copy the headers from the referenced ConfigMap into a ResponseHeaderModifier filter; " +
+				"later ConfigMap changes must be made on the HTTPRoute.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/http-header-modifier/",
+		},
+		{
+			Name:          "Preserve Trailing Slash",
+			Pattern:       "nginx.ingress.kubernetes.io/preserve-trailing-slash",
+			RiskLevel:     models.RiskManual,
+			Description:   "Keeps the trailing slash in SSL redirects",
+			MigrationNote: "Check how your Gateway's RequestRedirect treats trailing slashes.",
+			SourceURL:     nginxAnnotationDocs + "#ssl-redirect",
+		},
+		{
+			Name:        "Proxy HTTP Version",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-http-version",
+			RiskLevel:   models.RiskManual,
+			Description: "HTTP version used towards the backend",
+			MigrationNote: "Set the Service port appProtocol (e.g. kubernetes.io/h2c) for HTTP/2; forcing " +
+				"HTTP/1.0 needs implementation support.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/geps/gep-1911/",
+			Conditions: []models.ValueCondition{
+				{Value: "1\\.1", RiskLevel: models.RiskAuto, MigrationNote: "HTTP/1.1 is the default; nothing to migrate."},
+			},
+		},
+		{
+			Name:        "SSL Passthrough",
+			Pattern:     "nginx.ingress.kubernetes.io/ssl-passthrough",
+			RiskLevel:   models.RiskManual,
+			Description: "Forwards TLS connections to the backend without terminating them",
+			MigrationNote: "Use a Gateway listener with TLS mode Passthrough and a TLSRoute. TLSRoute is in the " +
+				"experimental channel, so check your Gateway's support.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/guides/tls/",
+			Conditions: []models.ValueCondition{
+				{Value: "false", RiskLevel: models.RiskAuto, MigrationNote: "Passthrough is off; nothing to migrate."},
+			},
+		},
+		{
+			Name:        "Service Upstream",
+			Pattern:     "nginx.ingress.kubernetes.io/service-upstream",
+			RiskLevel:   models.RiskManual,
+			Description: "Proxies to the Service ClusterIP instead of its endpoints",
+			MigrationNote: "Most Gateways load balance over endpoints. Review why the ClusterIP was needed, " +
+				"e.g. for a mesh sidecar or zero-downtime deploys.",
+			SourceURL: nginxAnnotationDocs + "#service-upstream",
+		},
+		{
+			Name:        "Connection Proxy Header",
+			Pattern:     "nginx.ingress.kubernetes.io/connection-proxy-header",
+			RiskLevel:   models.RiskManual,
+			Description: "Connection header sent to the backend",
+			MigrationNote: "Hop-by-hop headers are managed by the Gateway. Check whether the backend still " +
+				"needs the value, e.g. keep-alive.",
+			SourceURL: nginxAnnotationDocs + "#connection-proxy-header",
+		},
+
+		// Proxy timeouts, retries and buffering
+		{
+			Name:        "Proxy Next Upstream",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-next-upstream",
+			RiskLevel:   models.RiskManual,
+			Description: "Failures that make a request retry on another endpoint",
+			MigrationNote: "HTTPRoute retries (GEP-1731) take status codes and attempts; connection-level " +
+				"conditions need implementation policies.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/geps/gep-1731/",
+		},
+		{
+			Name:          "Proxy Next Upstream Timeout",
+			Pattern:       "nginx.ingress.kubernetes.io/proxy-next-upstream-timeout",
+			RiskLevel:     models.RiskManual,
+			Description:   "Time limit for retrying a request",
+			MigrationNote: "Map to the HTTPRoute request timeout (GEP-1742), which bounds all retries.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/geps/gep-1742/",
+		},
+		{
+			Name:        "Proxy Next Upstream Tries",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-next-upstream-tries",
+			RiskLevel:   models.RiskManual,
+			Description: "Maximum attempts for a request",
+			MigrationNote: "Map to retry attempts on the HTTPRoute rule (GEP-1731, experimental) or an " +
+				"implementation retry policy.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/geps/gep-1731/",
+		},
+		{
+			Name:        "Proxy Request Buffering",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-request-buffering",
+			RiskLevel:   models.RiskManual,
+			Description: "Whether request bodies are buffered before proxying",
+			MigrationNote: "Implementation-specific. Disabled buffering usually means streaming uploads; verify " +
+				"them against your Gateway.",
+			SourceURL: nginxAnnotationDocs + "#proxy-buffering",
+		},
+		{
+			Name:        "Proxy Buffering",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-buffering",
+			RiskLevel:   models.RiskManual,
+			Description: "Whether responses are buffered before sending them to clients",
+			MigrationNote: "Implementation-specific. Check streaming responses such as SSE, which often set " +
+				"this to off.",
+			SourceURL: nginxAnnotationDocs + "#proxy-buffering",
+		},
+		{
+			Name:          "Proxy Buffers Number",
+			Pattern:       "nginx.ingress.kubernetes.io/proxy-buffers-number",
+			RiskLevel:     models.RiskManual,
+			Description:   "Number of response buffers",
+			MigrationNote: "NGINX tuning with no Gateway API equivalent; usually safe to drop.",
+			SourceURL:     nginxAnnotationDocs + "#proxy-buffers-number",
+		},
+		{
+			Name:        "Proxy Buffer Size",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-buffer-size",
+			RiskLevel:   models.RiskManual,
+			Description: "Buffer size for response headers",
+			MigrationNote: "Often raised for large headers or cookies. Check your Gateway's maximum header " +
+				"size so these requests don't fail.",
+			SourceURL: nginxAnnotationDocs + "#proxy-buffer-size",
+		},
+		{
+			Name:          "Proxy Max Temp File Size",
+			Pattern:       "nginx.ingress.kubernetes.io/proxy-max-temp-file-size",
+			RiskLevel:     models.RiskManual,
+			Description:   "Maximum size of buffered responses written to disk",
+			MigrationNote: "NGINX tuning with no Gateway API equivalent; usually safe to drop.",
+			SourceURL:     nginxAnnotationDocs + "#proxy-max-temp-file-size",
+		},
+		{
+			Name:        "Proxy Cookie Domain",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-cookie-domain",
+			RiskLevel:   models.RiskManual,
+			Description: "Rewrites the domain of Set-Cookie headers",
+			MigrationNote: "Gateway API cannot rewrite parts of response headers. Change the cookie domain " +
+				"in the application or use an implementation policy.",
+			SourceURL: nginxAnnotationDocs + "#proxy-cookie-domain",
+		},
+		{
+			Name:        "Proxy Cookie Path",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-cookie-path",
+			RiskLevel:   models.RiskManual,
+			Description: "Rewrites the path of Set-Cookie headers",
+			MigrationNote: "Gateway API cannot rewrite parts of response headers. Change the cookie path " +
+				"in the application or use an implementation policy.",
+			SourceURL: nginxAnnotationDocs + "#proxy-cookie-path",
+		},
+		{
+			Name:        "Proxy Redirect From",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-redirect-from",
+			RiskLevel:   models.RiskManual,
+			Description: "Location header text replaced in backend redirects",
+			MigrationNote: "Gateway API cannot rewrite Location headers. Make the backend emit the public URL, " +
+				"e.g. from X-Forwarded-Host and X-Forwarded-Prefix.",
+			SourceURL: nginxAnnotationDocs + "#proxy-redirect",
+		},
+		{
+			Name:          "Proxy Redirect To",
+			Pattern:       "nginx.ingress.kubernetes.io/proxy-redirect-to",
+			RiskLevel:     models.RiskManual,
+			Description:   "Replacement for proxy-redirect-from in Location headers",
+			MigrationNote: "Migrate together with proxy-redirect-from.",
+			SourceURL:     nginxAnnotationDocs + "#proxy-redirect",
+		},
+
+		// Authentication
+		{
+			Name:        "Auth Type",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-type",
+			RiskLevel:   models.RiskManual,
+			Description: "Basic or digest authentication",
+			MigrationNote: "Gateway API has no standard authentication. Use an implementation policy (e.g. " +
+				"basic auth in a SecurityPolicy) or move authentication to the application.",
+			SourceURL: nginxAnnotationDocs + "#authentication",
+		},
+		{
+			Name:        "Auth Secret",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-secret",
+			RiskLevel:   models.RiskManual,
+			Description: "Secret holding the htpasswd users",
+			MigrationNote: "Reference the Secret from your Gateway's auth policy; check the format it expects " +
+				"and that it can read Secrets in this namespace.",
+			SourceURL: nginxAnnotationDocs + "#authentication",
+		},
+		{
+			Name:          "Auth Secret Type",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-secret-type",
+			RiskLevel:     models.RiskManual,
+			Description:   "Whether auth-secret is an auth-file or auth-map",
+			MigrationNote: "Convert the Secret to the format your Gateway's auth policy expects.",
+			SourceURL:     nginxAnnotationDocs + "#authentication",
+		},
+		{
+			Name:          "Auth Realm",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-realm",
+			RiskLevel:     models.RiskManual,
+			Description:   "Realm shown in the authentication prompt",
+			MigrationNote: "Cosmetic; set it on the auth policy if supported.",
+			SourceURL:     nginxAnnotationDocs + "#authentication",
+		},
+		{
+			Name:        "Auth Method",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-method",
+			RiskLevel:   models.RiskManual,
+			Description: "HTTP method of external auth requests",
+			MigrationNote: "Part of the external auth setup; check that your Gateway's ext-auth policy " +
+				"supports the method.",
+			SourceURL: nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:        "Auth Signin",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-signin",
+			RiskLevel:   models.RiskManual,
+			Description: "Login URL for unauthenticated requests",
+			MigrationNote: "Redirecting to a login page on 401 needs an implementation OIDC or ext-auth " +
+				"policy; oauth2-proxy setups usually move to the Gateway's OIDC support.",
+			SourceURL: nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:          "Auth Signin Redirect Param",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-signin-redirect-param",
+			RiskLevel:     models.RiskManual,
+			Description:   "Query parameter carrying the original URL to auth-signin",
+			MigrationNote: "Migrate together with auth-signin.",
+			SourceURL:     nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:        "Auth Response Headers",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-response-headers",
+			RiskLevel:   models.RiskManual,
+			Description: "Headers copied from the auth response to the upstream request",
+			MigrationNote: "Backends often trust these headers for identity. Make sure the ext-auth policy " +
+				"forwards them and that clients cannot set them.",
+			SourceURL: nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:          "Auth Request Redirect",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-request-redirect",
+			RiskLevel:     models.RiskManual,
+			Description:   "X-Auth-Request-Redirect header sent to the auth service",
+			MigrationNote: "Check whether your ext-auth policy can send this header to the auth service.",
+			SourceURL:     nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:          "Auth Always Set Cookie",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-always-set-cookie",
+			RiskLevel:     models.RiskManual,
+			Description:   "Sets auth cookies on error responses too",
+			MigrationNote: "Check how your ext-auth policy forwards Set-Cookie from the auth service.",
+			SourceURL:     nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:          "Auth Cache Key",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-cache-key",
+			RiskLevel:     models.RiskManual,
+			Description:   "Cache key for external auth responses",
+			MigrationNote: "Auth response caching is implementation-specific; without it every request reaches the auth service.",
+			SourceURL:     nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:          "Auth Cache Duration",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-cache-duration",
+			RiskLevel:     models.RiskManual,
+			Description:   "How long external auth responses are cached",
+			MigrationNote: "Migrate together with auth-cache-key.",
+			SourceURL:     nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:          "Auth Keepalive",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-keepalive",
+			RiskLevel:     models.RiskManual,
+			Description:   "Keepalive connections to the auth service",
+			MigrationNote: "Connection tuning for the auth service; usually handled by the Gateway.",
+			SourceURL:     nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:          "Auth Keepalive Share Vars",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-keepalive-share-vars",
+			RiskLevel:     models.RiskManual,
+			Description:   "Shares variables between auth subrequests",
+			MigrationNote: "NGINX internals with no Gateway equivalent; review with auth-keepalive.",
+			SourceURL:     nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:          "Auth Keepalive Requests",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-keepalive-requests",
+			RiskLevel:     models.RiskManual,
+			Description:   "Requests per keepalive connection to the auth service",
+			MigrationNote: "Connection tuning for the auth service; usually handled by the Gateway.",
+			SourceURL:     nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:          "Auth Keepalive Timeout",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-keepalive-timeout",
+			RiskLevel:     models.RiskManual,
+			Description:   "Idle timeout of keepalive connections to the auth service",
+			MigrationNote: "Connection tuning for the auth service; usually handled by the Gateway.",
+			SourceURL:     nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:          "Auth Proxy Set Headers",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-proxy-set-headers",
+			RiskLevel:     models.RiskManual,
+			Description:   "ConfigMap of headers sent to the auth service",
+			MigrationNote: "Copy the headers into your ext-auth policy if it supports extra headers.",
+			SourceURL:     nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:        "Enable Global Auth",
+			Pattern:     "nginx.ingress.kubernetes.io/enable-global-auth",
+			RiskLevel:   models.RiskManual,
+			Description: "Opts the Ingress in or out of the controller-wide global-auth-url",
+			MigrationNote: "Global auth has no Gateway API equivalent. Attach the auth policy to the Gateway or " +
+				"to each HTTPRoute that should be protected.",
+			SourceURL: nginxAnnotationDocs + "#global-external-authentication",
+		},
+		{
+			Name:        "Satisfy",
+			Pattern:     "nginx.ingress.kubernetes.io/satisfy",
+			RiskLevel:   models.RiskManual,
+			Description: "Whether any or all access checks must pass",
+			MigrationNote: "Combining auth with IP allowlists as any/all is implementation-specific; check that " +
+				"your policies compose the same way.",
+			SourceURL: nginxAnnotationDocs + "#satisfy",
+		},
+
+		// Client certificate authentication
+		{
+			Name:        "Auth TLS Secret",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-tls-secret",
+			RiskLevel:   models.RiskManual,
+			Description: "CA certificate for verifying client certificates",
+			MigrationNote: "Use Gateway frontend TLS validation (GEP-91), which applies per listener or port " +
+				"rather than per Ingress, or an implementation policy.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/geps/gep-91/",
+		},
+		{
+			Name:        "Auth TLS Verify Client",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-tls-verify-client",
+			RiskLevel:   models.RiskManual,
+			Description: "Whether client certificates are required, optional or unchecked",
+			MigrationNote: "Check that your Gateway's client validation supports optional verification; " +
+				"optional_no_ca has no common equivalent.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/geps/gep-91/",
+		},
+		{
+			Name:          "Auth TLS Verify Depth",
+			Pattern:       "nginx.ingress.kubernetes.io/auth-tls-verify-depth",
+			RiskLevel:     models.RiskManual,
+			Description:   "Maximum client certificate chain depth",
+			MigrationNote: "Implementation-specific; review with the client CA setup.",
+			SourceURL:     nginxAnnotationDocs + "#client-certificate-authentication",
+		},
+		{
+			Name:        "Auth TLS Error Page",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-tls-error-page",
+			RiskLevel:   models.RiskManual,
+			Description: "Page shown when client certificate verification fails",
+			MigrationNote: "Failed verification usually ends the TLS handshake on a Gateway, so clients get no " +
+				"error page.",
+			SourceURL: nginxAnnotationDocs + "#client-certificate-authentication",
+		},
+		{
+			Name:        "Auth TLS Pass Certificate To Upstream",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-tls-pass-certificate-to-upstream",
+			RiskLevel:   models.RiskManual,
+			Description: "Sends the client certificate to the backend in ssl-client-cert",
+			MigrationNote: "Forwarding client certificates (e.g. X-Forwarded-Client-Cert) is implementation-specific " +
+				"and uses a different header; update the backend.",
+			SourceURL: nginxAnnotationDocs + "#client-certificate-authentication",
+		},
+		{
+			Name:        "Auth TLS Match CN",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-tls-match-cn",
+			RiskLevel:   models.RiskManual,
+			Description: "Regex the client certificate CN must match",
+			MigrationNote: "Gateway API does not restrict client certificate subjects. Without an implementation " +
+				"policy any certificate from the CA is accepted.",
+			SourceURL: nginxAnnotationDocs + "#client-certificate-authentication",
+		},
+
+		// Backend TLS
+		{
+			Name:        "Proxy SSL Secret",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-ssl-secret",
+			RiskLevel:   models.RiskManual,
+			Description: "Client certificate and CA for TLS to the backend",
+			MigrationNote: "BackendTLSPolicy covers the CA and hostname; presenting a client certificate to the " +
+				"backend needs implementation support.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/api-types/backendtlspolicy/",
+		},
+		{
+			Name:        "Proxy SSL Verify",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-ssl-verify",
+			RiskLevel:   models.RiskManual,
+			Description: "Whether the backend certificate is verified",
+			MigrationNote: "Use a BackendTLSPolicy with the backend CA. Gateways that verify by default will " +
+				"fail against backends that relied on verification being off.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/api-types/backendtlspolicy/",
+		},
+		{
+			Name:          "Proxy SSL Verify Depth",
+			Pattern:       "nginx.ingress.kubernetes.io/proxy-ssl-verify-depth",
+			RiskLevel:     models.RiskManual,
+			Description:   "Maximum backend certificate chain depth",
+			MigrationNote: "Implementation-specific; review with the BackendTLSPolicy.",
+			SourceURL:     nginxAnnotationDocs + "#backend-certificate-authentication",
+		},
+		{
+			Name:          "Proxy SSL Name",
+			Pattern:       "nginx.ingress.kubernetes.io/proxy-ssl-name",
+			RiskLevel:     models.RiskManual,
+			Description:   "Name used to verify the backend certificate",
+			MigrationNote: "Set validation.hostname on the BackendTLSPolicy.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/api-types/backendtlspolicy/",
+		},
+		{
+			Name:        "Proxy SSL Server Name",
+			Pattern:     "nginx.ingress.kubernetes.io/proxy-ssl-server-name",
+			RiskLevel:   models.RiskManual,
+			Description: "Whether SNI is sent to the backend",
+			MigrationNote: "BackendTLSPolicy sends validation.hostname as SNI; check backends that need a " +
+				"different name.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/api-types/backendtlspolicy/",
+		},
+		{
+			Name:          "Proxy SSL Ciphers",
+			Pattern:       "nginx.ingress.kubernetes.io/proxy-ssl-ciphers",
+			RiskLevel:     models.RiskManual,
+			Description:   "Ciphers for TLS to the backend",
+			MigrationNote: "Implementation-specific; check the Gateway's upstream TLS settings.",
+			SourceURL:     nginxAnnotationDocs + "#backend-certificate-authentication",
+		},
+		{
+			Name:          "Proxy SSL Protocols",
+			Pattern:       "nginx.ingress.kubernetes.io/proxy-ssl-protocols",
+			RiskLevel:     models.RiskManual,
+			Description:   "TLS versions for connections to the backend",
+			MigrationNote: "Implementation-specific; check the Gateway's upstream TLS settings.",
+			SourceURL:     nginxAnnotationDocs + "#backend-certificate-authentication",
+		},
+		{
+			Name:        "SSL Ciphers",
+			Pattern:     "nginx.ingress.kubernetes.io/ssl-ciphers",
+			RiskLevel:   models.RiskManual,
+			Description: "Ciphers offered to clients",
+			MigrationNote: "Configure through the Gateway listener tls.options, which are implementation-specific " +
+				"and apply to every route on the listener.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/reference/spec/#gatewaytlsconfig",
+		},
+		{
+			Name:          "SSL Prefer Server Ciphers",
+			Pattern:       "nginx.ingress.kubernetes.io/ssl-prefer-server-ciphers",
+			RiskLevel:     models.RiskManual,
+			Description:   "Prefers server over client cipher order",
+			MigrationNote: "Configure through the Gateway listener tls.options if supported.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/reference/spec/#gatewaytlsconfig",
+		},
+
+		// CORS
+		{
+			Name:        "CORS Allow Origin",
+			Pattern:     "nginx.ingress.kubernetes.io/cors-allow-origin",
+			RiskLevel:   models.RiskManual,
+			Description: "Origins allowed by CORS",
+			MigrationNote: "Map to allowOrigins of the HTTPRoute CORS filter (GEP-1767, experimental) or an " +
+				"implementation CORS policy.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/geps/gep-1767/",
+		},
+		{
+			Name:          "CORS Allow Methods",
+			Pattern:       "nginx.ingress.kubernetes.io/cors-allow-methods",
+			RiskLevel:     models.RiskManual,
+			Description:   "Methods allowed by CORS",
+			MigrationNote: "Map to allowMethods of the CORS filter or policy.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/geps/gep-1767/",
+		},
+		{
+			Name:          "CORS Allow Headers",
+			Pattern:       "nginx.ingress.kubernetes.io/cors-allow-headers",
+			RiskLevel:     models.RiskManual,
+			Description:   "Request headers allowed by CORS",
+			MigrationNote: "Map to allowHeaders of the CORS filter or policy.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/geps/gep-1767/",
+		},
+		{
+			Name:          "CORS Expose Headers",
+			Pattern:       "nginx.ingress.kubernetes.io/cors-expose-headers",
+			RiskLevel:     models.RiskManual,
+			Description:   "Response headers exposed to browsers",
+			MigrationNote: "Map to exposeHeaders of the CORS filter or policy.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/geps/gep-1767/",
+		},
+		{
+			Name:          "CORS Allow Credentials",
+			Pattern:       "nginx.ingress.kubernetes.io/cors-allow-credentials",
+			RiskLevel:     models.RiskManual,
+			Description:   "Whether CORS requests may carry credentials",
+			MigrationNote: "Map to allowCredentials of the CORS filter or policy.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/geps/gep-1767/",
+		},
+		{
+			Name:          "CORS Max Age",
+			Pattern:       "nginx.ingress.kubernetes.io/cors-max-age",
+			RiskLevel:     models.RiskManual,
+			Description:   "How long preflight responses are cached",
+			MigrationNote: "Map to maxAge of the CORS filter or policy.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/geps/gep-1767/",
+		},
+
+		// Access control and rate limiting
+		{
+			Name:        "Whitelist Source Range",
+			Pattern:     "nginx.ingress.kubernetes.io/whitelist-source-range",
+			RiskLevel:   models.RiskManual,
+			Description: "Client CIDRs allowed to reach the Ingress",
+			MigrationNote: "Gateway API has no IP allowlist. Recreate it with an implementation authorization " +
+				"policy or loadBalancerSourceRanges; otherwise the restriction is silently lost.",
+			SourceURL: nginxAnnotationDocs + "#whitelist-source-range",
+		},
+		{
+			Name:        "Denylist Source Range",
+			Pattern:     "nginx.ingress.kubernetes.io/denylist-source-range",
+			RiskLevel:   models.RiskManual,
+			Description: "Client CIDRs blocked from the Ingress",
+			MigrationNote: "Gateway API has no IP denylist. Recreate it with an implementation authorization " +
+				"policy; otherwise the restriction is silently lost.",
+			SourceURL: nginxAnnotationDocs + "#denylist-source-range",
+		},
+		{
+			Name:        "Limit RPS",
+			Pattern:     "nginx.ingress.kubernetes.io/limit-rps",
+			RiskLevel:   models.RiskManual,
+			Description: "Requests per second per client IP",
+			MigrationNote: "Gateway API has no standard rate limiting; use an implementation rate limit policy. " +
+				"NGINX limits are per controller replica, so recompute limits that are global.",
+			SourceURL: nginxAnnotationDocs + "#rate-limiting",
+		},
+		{
+			Name:        "Limit RPM",
+			Pattern:     "nginx.ingress.kubernetes.io/limit-rpm",
+			RiskLevel:   models.RiskManual,
+			Description: "Requests per minute per client IP",
+			MigrationNote: "Gateway API has no standard rate limiting; use an implementation rate limit policy. " +
+				"NGINX limits are per controller replica, so recompute limits that are global.",
+			SourceURL: nginxAnnotationDocs + "#rate-limiting",
+		},
+		{
+			Name:          "Limit Burst Multiplier",
+			Pattern:       "nginx.ingress.kubernetes.io/limit-burst-multiplier",
+			RiskLevel:     models.RiskManual,
+			Description:   "Burst size as a multiple of the rate limit",
+			MigrationNote: "Migrate together with limit-rps or limit-rpm.",
+			SourceURL:     nginxAnnotationDocs + "#rate-limiting",
+		},
+		{
+			Name:        "Limit Connections",
+			Pattern:     "nginx.ingress.kubernetes.io/limit-connections",
+			RiskLevel:   models.RiskManual,
+			Description: "Concurrent connections per client IP",
+			MigrationNote: "Per-client connection limits need an implementation policy; circuit breakers limit " +
+				"connections per backend instead.",
+			SourceURL: nginxAnnotationDocs + "#rate-limiting",
+		},
+		{
+			Name:          "Limit Whitelist",
+			Pattern:       "nginx.ingress.kubernetes.io/limit-whitelist",
+			RiskLevel:     models.RiskManual,
+			Description:   "Client CIDRs exempt from rate limits",
+			MigrationNote: "Check whether your rate limit policy can exempt clients by CIDR.",
+			SourceURL:     nginxAnnotationDocs + "#rate-limiting",
+		},
+		{
+			Name:          "Limit Rate",
+			Pattern:       "nginx.ingress.kubernetes.io/limit-rate",
+			RiskLevel:     models.RiskManual,
+			Description:   "Bandwidth limit per response",
+			MigrationNote: "Response bandwidth limits are rarely supported by Gateways; review whether it is still needed.",
+			SourceURL:     nginxAnnotationDocs + "#rate-limiting",
+		},
+		{
+			Name:          "Limit Rate After",
+			Pattern:       "nginx.ingress.kubernetes.io/limit-rate-after",
+			RiskLevel:     models.RiskManual,
+			Description:   "Bytes sent before limit-rate applies",
+			MigrationNote: "Migrate together with limit-rate.",
+			SourceURL:     nginxAnnotationDocs + "#rate-limiting",
+		},
+
+		// Session affinity and load balancing
+		{
+			Name:        "Affinity",
+			Pattern:     "nginx.ingress.kubernetes.io/affinity",
+			RiskLevel:   models.RiskManual,
+			Description: "Cookie-based session affinity",
+			MigrationNote: "Use sessionPersistence on the HTTPRoute rule (GEP-1619, experimental) or an " +
+				"implementation policy. Stateful applications break if affinity is lost.",
+			SourceURL: "https://gateway-api.sigs.k8s.io/geps/gep-1619/",
+		},
+		{
+			Name:          "Affinity Mode",
+			Pattern:       "nginx.ingress.kubernetes.io/affinity-mode",
+			RiskLevel:     models.RiskManual,
+			Description:   "Whether sessions are rebalanced when endpoints scale (balanced or persistent)",
+			MigrationNote: "Check how your Gateway's session persistence behaves when endpoints change.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/geps/gep-1619/",
+		},
+		{
+			Name:          "Session Cookie Name",
+			Pattern:       "nginx.ingress.kubernetes.io/session-cookie-name",
+			RiskLevel:     models.RiskManual,
+			Description:   "Name of the affinity cookie",
+			MigrationNote: "Set sessionName in sessionPersistence. Existing sessions move once when the cookie changes.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/geps/gep-1619/",
+		},
+		{
+			Name:          "Session Cookie Path",
+			Pattern:       "nginx.ingress.kubernetes.io/session-cookie-path",
+			RiskLevel:     models.RiskManual,
+			Description:   "Path of the affinity cookie",
+			MigrationNote: "Cookie attributes are implementation-specific; check your Gateway's session persistence options.",
+			SourceURL:     nginxAnnotationDocs + "#session-affinity",
+		},
+		{
+			Name:          "Session Cookie Domain",
+			Pattern:       "nginx.ingress.kubernetes.io/session-cookie-domain",
+			RiskLevel:     models.RiskManual,
+			Description:   "Domain of the affinity cookie",
+			MigrationNote: "Cookie attributes are implementation-specific; check your Gateway's session persistence options.",
+			SourceURL:     nginxAnnotationDocs + "#session-affinity",
+		},
+		{
+			Name:          "Session Cookie SameSite",
+			Pattern:       "nginx.ingress.kubernetes.io/session-cookie-samesite",
+			RiskLevel:     models.RiskManual,
+			Description:   "SameSite attribute of the affinity cookie",
+			MigrationNote: "Cookie attributes are implementation-specific; check your Gateway's session persistence options.",
+			SourceURL:     nginxAnnotationDocs + "#session-affinity",
+		},
+		{
+			Name:          "Session Cookie Conditional SameSite None",
+			Pattern:       "nginx.ingress.kubernetes.io/session-cookie-conditional-samesite-none",
+			RiskLevel:     models.RiskManual,
+			Description:   "Omits SameSite=None for clients that reject it",
+			MigrationNote: "User-agent specific cookie handling has no Gateway equivalent; check the affected clients.",
+			SourceURL:     nginxAnnotationDocs + "#session-affinity",
+		},
+		{
+			Name:          "Session Cookie Secure",
+			Pattern:       "nginx.ingress.kubernetes.io/session-cookie-secure",
+			RiskLevel:     models.RiskManual,
+			Description:   "Secure attribute of the affinity cookie",
+			MigrationNote: "Cookie attributes are implementation-specific; check your Gateway's session persistence options.",
+			SourceURL:     nginxAnnotationDocs + "#session-affinity",
+		},
+		{
+			Name:          "Session Cookie Max Age",
+			Pattern:       "nginx.ingress.kubernetes.io/session-cookie-max-age",
+			RiskLevel:     models.RiskManual,
+			Description:   "Max-Age of the affinity cookie",
+			MigrationNote: "Map to absoluteTimeout in sessionPersistence with a Permanent cookie lifetime.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/geps/gep-1619/",
+		},
+		{
+			Name:          "Session Cookie Expires",
+			Pattern:       "nginx.ingress.kubernetes.io/session-cookie-expires",
+			RiskLevel:     models.RiskManual,
+			Description:   "Expires attribute of the affinity cookie",
+			MigrationNote: "Map to absoluteTimeout in sessionPersistence with a Permanent cookie lifetime.",
+			SourceURL:     "https://gateway-api.sigs.k8s.io/geps/gep-1619/",
+		},
+		{
+			Name:          "Session Cookie Change On Failure",
+			Pattern:       "nginx.ingress.kubernetes.io/session-cookie-change-on-failure",
+			RiskLevel:     models.RiskManual,
+			Description:   "Re-pins sessions when their endpoint fails",
+			MigrationNote: "Check how your Gateway handles sessions pinned to an unhealthy endpoint.",
+			SourceURL:     nginxAnnotationDocs + "#session-affinity",
+		},
+		{
+			Name:        "Upstream Hash By",
+			Pattern:     "nginx.ingress.kubernetes.io/upstream-hash-by",
+			RiskLevel:   models.RiskManual,
+			Description: "Consistent hashing key for choosing endpoints",
+			MigrationNote: "Use an implementation load balancing policy with consistent hashing (e.g. ring hash " +
+				"on a header or source IP); NGINX variables must be translated.",
+			SourceURL: nginxAnnotationDocs + "#custom-nginx-upstream-hashing",
+		},
+		{
+			Name:          "Upstream Hash By Subset",
+			Pattern:       "nginx.ingress.kubernetes.io/upstream-hash-by-subset",
+			RiskLevel:     models.RiskManual,
+			Description:   "Hashes to a subset of endpoints instead of one",
+			MigrationNote: "Subset hashing is NGINX-specific; review whether plain consistent hashing is enough.",
+			SourceURL:     nginxAnnotationDocs + "#custom-nginx-upstream-hashing",
+		},
+		{
+			Name:          "Upstream Hash By Subset Size",
+			Pattern:       "nginx.ingress.kubernetes.io/upstream-hash-by-subset-size",
+			RiskLevel:     models.RiskManual,
+			Description:   "Endpoints per subset for upstream-hash-by-subset",
+			MigrationNote: "Migrate together with upstream-hash-by-subset.",
+			SourceURL:     nginxAnnotationDocs + "#custom-nginx-upstream-hashing",
+		},
+		{
+			Name:          "Load Balance",
+			Pattern:       "nginx.ingress.kubernetes.io/load-balance",
+			RiskLevel:     models.RiskManual,
+			Description:   "Load balancing algorithm (round_robin or ewma)",
+			MigrationNote: "Use an implementation load balancing policy; EWMA maps to least-request on most Gateways.",
+			SourceURL:     nginxAnnotationDocs + "#custom-nginx-load-balancing",
+		},
+
+		// Observability
+		{
+			Name:          "Enable Access Log",
+			Pattern:       "nginx.ingress.kubernetes.io/enable-access-log",
+			RiskLevel:     models.RiskManual,
+			Description:   "Turns access logging on or off for the Ingress",
+			MigrationNote: "Access logging is configured per Gateway, not per route; check whether it can be filtered.",
+			SourceURL:     nginxAnnotationDocs + "#enable-access-log",
+		},
+		{
+			Name:          "Enable Rewrite Log",
+			Pattern:       "nginx.ingress.kubernetes.io/enable-rewrite-log",
+			RiskLevel:     models.RiskManual,
+			Description:   "Logs rewrite decisions for debugging",
+			MigrationNote: "NGINX debugging aid; safe to drop.",
+			SourceURL:     nginxAnnotationDocs + "#enable-rewrite-log",
+		},
+		{
+			Name:          "Enable OpenTelemetry",
+			Pattern:       "nginx.ingress.kubernetes.io/enable-opentelemetry",
+			RiskLevel:     models.RiskManual,
+			Description:   "Turns OpenTelemetry tracing on or off for the Ingress",
+			MigrationNote: "Tracing is configured per Gateway or through implementation policies; check per-route control.",
+			SourceURL:     nginxAnnotationDocs + "#enable-opentelemetry",
+		},
+		{
+			Name:          "OpenTelemetry Trust Incoming Span",
+			Pattern:       "nginx.ingress.kubernetes.io/opentelemetry-trust-incoming-span",
+			RiskLevel:     models.RiskManual,
+			Description:   "Whether incoming trace context is continued",
+			MigrationNote: "Check your Gateway's trace propagation settings.",
+			SourceURL:     nginxAnnotationDocs + "#opentelemetry-trust-incoming-span",
+		},
+
+		// Tier C - HIGH_RISK (complex configurations needing careful planning)
+		{
+			Name:        "Auth Snippet",
+			Pattern:     "nginx.ingress.kubernetes.io/auth-snippet",
+			RiskLevel:   models.RiskHigh,
+			Description: "Custom NGINX configuration for the external auth location",
+			MigrationNote: "Custom NGINX configuration has no Gateway API equivalent. Review what the snippet does " +
+				"to auth requests and reimplement it in the auth service or Gateway policy.",
+			SourceURL: nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name:        "Enable ModSecurity",
+			Pattern:     "nginx.ingress.kubernetes.io/enable-modsecurity",
+			RiskLevel:   models.RiskHigh,
+			Description: "Enables the ModSecurity web application firewall",
+			MigrationNote: "Gateway API has no WAF. Plan a replacement (Gateway WAF extension, cloud WAF or " +
+				"a proxy in front) before migrating, or the protection is lost.",
+			SourceURL: nginxAnnotationDocs + "#modsecurity",
+			Conditions: []models.ValueCondition{
+				{Value: "false", RiskLevel: models.RiskAuto, MigrationNote: "ModSecurity is off for this Ingress; nothing to migrate."},
+			},
+		},
+		{
+			Name:        "Enable OWASP Core Rules",
+			Pattern:     "nginx.ingress.kubernetes.io/enable-owasp-core-rules",
+			RiskLevel:   models.RiskHigh,
+			Description: "Enables the OWASP Core Rule Set in ModSecurity",
+			MigrationNote: "Needs a WAF replacement that runs the OWASP Core Rule Set, such as Coraza, with " +
+				"tuning exclusions carried over.",
+			SourceURL: nginxAnnotationDocs + "#modsecurity",
+			Conditions: []models.ValueCondition{
+				{Value: "false", RiskLevel: models.RiskAuto, MigrationNote: "The Core Rule Set is off; nothing to migrate."},
+			},
+		},
+		{
+			Name:          "ModSecurity Transaction ID",
+			Pattern:       "nginx.ingress.kubernetes.io/modsecurity-transaction-id",
+			RiskLevel:     models.RiskHigh,
+			Description:   "Transaction ID used in ModSecurity logs",
+			MigrationNote: "Migrate with the WAF replacement; log correlation depends on the new WAF.",
+			SourceURL:     nginxAnnotationDocs + "#modsecurity",
+		},
+		{
+			Name:        "ModSecurity Snippet",
+			Pattern:     "nginx.ingress.kubernetes.io/modsecurity-snippet",
+			RiskLevel:   models.RiskHigh,
+			Description: "Custom ModSecurity rules and settings",
+			MigrationNote: "Custom WAF rules must be ported to the replacement WAF and retested; SecRule syntax " +
+				"only carries over to ModSecurity-compatible engines.",
+			SourceURL: nginxAnnotationDocs + "#modsecurity",
+		},
+	}
+}
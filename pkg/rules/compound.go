@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"fmt"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// GetCompoundRules returns the rules for annotation combinations whose risk
+// or migration path differs from that of the annotations on their own
+func GetCompoundRules() []models.CompoundRule {
+	return []models.CompoundRule{
+		{
+			Name: "Regex Rewrite",
+			Annotations: []models.AnnotationCondition{
+				{Pattern: "nginx.ingress.kubernetes.io/use-regex", Value: "true"},
+				{Pattern: "nginx.ingress.kubernetes.io/rewrite-target", Value: `.*\$[0-9].*`},
+			},
+			RiskLevel: models.RiskManual,
+			Warning: "use-regex with a capture-group rewrite-target makes every path of the Ingress a regex whose " +
+				"groups feed the rewrite: translate each path to a RegularExpression match and check that your " +
+				"Gateway can rewrite with capture groups, which URLRewrite cannot",
+			SourceURL: nginxAnnotationDocs + "#rewrite",
+		},
+		{
+			Name: "Canary By Header And Weight",
+			Annotations: []models.AnnotationCondition{
+				{Pattern: "nginx.ingress.kubernetes.io/canary", Value: "true"},
+				{Pattern: "nginx.ingress.kubernetes.io/canary-by-header"},
+				{Pattern: "nginx.ingress.kubernetes.io/canary-weight"},
+			},
+			RiskLevel: models.RiskManual,
+			Warning: "ingress-nginx checks canary-by-header before canary-weight, and a header set to \"never\" skips " +
+				"the weighted split: order the HTTPRoute rules as header \"always\" to the canary, header \"never\" " +
+				"to the primary, then the weighted backendRefs",
+			SourceURL: nginxAnnotationDocs + "#canary",
+		},
+		{
+			Name: "External Auth Snippet",
+			Annotations: []models.AnnotationCondition{
+				{Pattern: "nginx.ingress.kubernetes.io/auth-url"},
+				{Pattern: "nginx.ingress.kubernetes.io/auth-snippet"},
+			},
+			RiskLevel: models.RiskHigh,
+			Warning: "auth-snippet customizes the auth-url subrequest with NGINX configuration that no ext-auth " +
+				"policy can run: move that logic into the auth service before migrating the external auth",
+			SourceURL: nginxAnnotationDocs + "#external-authentication",
+		},
+		{
+			Name: "Conflicting SSL Redirects",
+			Annotations: []models.AnnotationCondition{
+				{Pattern: "nginx.ingress.kubernetes.io/ssl-redirect", Value: "false"},
+				{Pattern: "nginx.ingress.kubernetes.io/force-ssl-redirect", Value: "true"},
+			},
+			Warning: "force-ssl-redirect overrides ssl-redirect: \"false\", so HTTP requests are still redirected " +
+				"to HTTPS; migrate the redirect rather than serving the routes over plain HTTP",
+			SourceURL: nginxAnnotationDocs + "#server-side-https-enforcement-through-redirect",
+		},
+		{
+			Name: "Client Certificates Not Verified",
+			Annotations: []models.AnnotationCondition{
+				{Pattern: "nginx.ingress.kubernetes.io/auth-tls-secret"},
+				{Pattern: "nginx.ingress.kubernetes.io/auth-tls-verify-client", Value: "off"},
+			},
+			RiskLevel: models.RiskAuto,
+			Warning: "auth-tls-verify-client is off, so clients are never asked for a certificate and the " +
+				"auth-tls-secret CA is unused: no client certificate validation is needed on the Gateway",
+			SourceURL: nginxAnnotationDocs + "#client-certificate-authentication",
+		},
+	}
+}
+
+// MatchCompoundRules finds the compound rules whose annotations are all set
+func MatchCompoundRules(annotations map[string]string) []models.CompoundRule {
+	return MatchCompoundRulesWithPrefix(annotations, DefaultAnnotationPrefix)
+}
+
+// MatchCompoundRulesWithPrefix finds the compound rules whose annotations
+// are all set for a controller running with a custom --annotation-prefix
+func MatchCompoundRulesWithPrefix(annotations map[string]string, prefix string) []models.CompoundRule {
+	// Canonical keys, so that conditions can use the default prefix
+	canonical := make(map[string]string)
+	for key, value := range annotations {
+		if canonicalKey, ok := CanonicalAnnotationKey(key, prefix); ok {
+			canonical[canonicalKey] = value
+		}
+	}
+
+	var matched []models.CompoundRule
+	for _, compound := range GetCompoundRules() {
+		if matchesAllConditions(compound.Annotations, canonical) {
+			matched = append(matched, compound)
+		}
+	}
+
+	return matched
+}
+
+// matchesAllConditions reports whether every condition matches one of the
+// canonical annotations
+func matchesAllConditions(conditions []models.AnnotationCondition, canonical map[string]string) bool {
+	for _, condition := range conditions {
+		found := false
+		for key, value := range canonical {
+			if patternMatches(condition.Pattern, key) && (condition.Value == "" || valueMatches(condition.Value, value)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyCompoundRules returns the matched annotation rules with the risk
+// level and guidance of the compound rules covering them, so the note of an
+// annotation explains its new risk. An annotation covered by several
+// compound rules takes the one with the highest risk level.
+func ApplyCompoundRules(matchedRules []models.AnnotationRule, compounds []models.CompoundRule) []models.AnnotationRule {
+	applied := make([]models.AnnotationRule, len(matchedRules))
+	for i, rule := range matchedRules {
+		var covering *models.CompoundRule
+		for j, compound := range compounds {
+			if compound.RiskLevel == "" || !coversRule(compound, rule) {
+				continue
+			}
+			if covering == nil || HigherRisk(compound.RiskLevel, covering.RiskLevel) {
+				covering = &compounds[j]
+			}
+		}
+		if covering != nil {
+			rule.RiskLevel = covering.RiskLevel
+			rule.MigrationNote = fmt.Sprintf("Combined with other annotations (%s): %s.", covering.Name, covering.Warning)
+			rule.SourceURL = covering.SourceURL
+		}
+		applied[i] = rule
+	}
+
+	return applied
+}
+
+// coversRule reports whether one of the annotations of compound is the one
+// matched by rule
+func coversRule(compound models.CompoundRule, rule models.AnnotationRule) bool {
+	for _, condition := range compound.Annotations {
+		if patternMatches(condition.Pattern, rule.Pattern) {
+			return true
+		}
+	}
+	return false
+}
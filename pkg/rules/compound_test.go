@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+func TestMatchCompoundRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantRules   []string
+		wantRisk    models.RiskLevel
+	}{
+		{
+			name: "regex rewrite",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/use-regex":      "true",
+				"nginx.ingress.kubernetes.io/rewrite-target": "/$2",
+			},
+			wantRules: []string{"Regex Rewrite"},
+			wantRisk:  models.RiskManual,
+		},
+		{
+			name: "regex rewrite without capture groups",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/use-regex":      "true",
+				"nginx.ingress.kubernetes.io/rewrite-target": "/",
+			},
+			wantRisk: models.RiskManual,
+		},
+		{
+			name: "canary by header and weight raises the risk",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/canary":           "true",
+				"nginx.ingress.kubernetes.io/canary-by-header": "X-Canary",
+				"nginx.ingress.kubernetes.io/canary-weight":    "10",
+			},
+			wantRules: []string{"Canary By Header And Weight"},
+			wantRisk:  models.RiskManual,
+		},
+		{
+			name: "canary by weight only",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/canary":        "true",
+				"nginx.ingress.kubernetes.io/canary-weight": "10",
+			},
+			wantRisk: models.RiskAuto,
+		},
+		{
+			name: "conflicting SSL redirects keep their risk",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/ssl-redirect":       "false",
+				"nginx.ingress.kubernetes.io/force-ssl-redirect": "True",
+			},
+			wantRules: []string{"Conflicting SSL Redirects"},
+			wantRisk:  models.RiskAuto,
+		},
+		{
+			name: "unverified client certificates lower the risk",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-tls-secret":        "shop/client-ca",
+				"nginx.ingress.kubernetes.io/auth-tls-verify-client": "off",
+			},
+			wantRules: []string{"Client Certificates Not Verified"},
+			wantRisk:  models.RiskAuto,
+		},
+		{
+			name: "external auth snippet",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/auth-url":     "https://auth.example.com/verify",
+				"nginx.ingress.kubernetes.io/auth-snippet": "proxy_set_header X-Team shop;",
+			},
+			wantRules: []string{"External Auth Snippet"},
+			wantRisk:  models.RiskHigh,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compounds := MatchCompoundRules(tt.annotations)
+			var names []string
+			for _, compound := range compounds {
+				names = append(names, compound.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantRules) {
+				t.Errorf("MatchCompoundRules() = %v, want %v", names, tt.wantRules)
+			}
+
+			matched := ApplyCompoundRules(MatchAnnotations(tt.annotations), compounds)
+			if risk := GetHighestRiskLevel(matched); risk != tt.wantRisk {
+				t.Errorf("risk = %s, want %s", risk, tt.wantRisk)
+			}
+		})
+	}
+}
+
+func TestApplyCompoundRulesExplainsTheNewRisk(t *testing.T) {
+	annotations := map[string]string{
+		"nginx.ingress.kubernetes.io/auth-tls-secret":        "shop/client-ca",
+		"nginx.ingress.kubernetes.io/auth-tls-verify-client": "off",
+		"nginx.ingress.kubernetes.io/ssl-redirect":           "true",
+	}
+
+	for _, rule := range ApplyCompoundRules(MatchAnnotations(annotations), MatchCompoundRules(annotations)) {
+		covered := strings.Contains(rule.MigrationNote, "Client Certificates Not Verified")
+		if rule.Pattern == "nginx.ingress.kubernetes.io/ssl-redirect" {
+			if covered {
+				t.Errorf("ssl-redirect note = %q, want the note of its own rule", rule.MigrationNote)
+			}
+			continue
+		}
+		if rule.RiskLevel != models.RiskAuto || !covered || strings.Contains(rule.MigrationNote, "GEP-91") {
+			t.Errorf("%s = %s %q, want AUTO with the compound guidance", rule.Pattern, rule.RiskLevel, rule.MigrationNote)
+		}
+	}
+}
+
+func TestMatchCompoundRulesWithPrefix(t *testing.T) {
+	annotations := map[string]string{
+		"internal.ingress.example.com/auth-url":     "https://auth.example.com/verify",
+		"internal.ingress.example.com/auth-snippet": "proxy_set_header X-Team shop;",
+	}
+
+	if compounds := MatchCompoundRulesWithPrefix(annotations, "internal.ingress.example.com"); len(compounds) != 1 {
+		t.Errorf("MatchCompoundRulesWithPrefix() = %v, want External Auth Snippet", compounds)
+	}
+	if compounds := MatchCompoundRules(annotations); len(compounds) != 0 {
+		t.Errorf("MatchCompoundRules() = %v, want none with the default prefix", compounds)
+	}
+}
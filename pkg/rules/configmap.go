@@ -0,0 +1,392 @@
+package rules
+
+import (
+	"sort"
+	"strings"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+const configMapDocsURL = "https://kubernetes.github.io/ingress-nginx/user-guide/nginx-configuration/configmap/"
+
+// featureToggles are ConfigMap keys that only matter for migration when the
+// feature is switched on
+var featureToggles = map[string]bool{
+	"allow-snippet-annotations":    true,
+	"use-forwarded-headers":        true,
+	"compute-full-forwarded-for":   true,
+	"use-proxy-protocol":           true,
+	"enable-opentracing":           true,
+	"enable-opentelemetry":         true,
+	"enable-modsecurity":           true,
+	"enable-owasp-modsecurity-crs": true,
+	"use-gzip":                     true,
+	"enable-brotli":                true,
+	"hsts":                         true,
+}
+
+// defaultOn are feature toggles that ingress-nginx switches on when the
+// ConfigMap does not set them, so an absent key still needs migrating
+var defaultOn = map[string]bool{
+	"hsts": true,
+}
+
+// toggledSettings are ConfigMap keys that only take effect while the feature
+// toggle they map to is on
+var toggledSettings = map[string]string{
+	"hsts-max-age":            "hsts",
+	"hsts-include-subdomains": "hsts",
+	"hsts-preload":            "hsts",
+}
+
+// GetConfigMapRules returns the classification rules for global settings in
+// the ingress-nginx controller ConfigMap. Patterns are ConfigMap keys.
+func GetConfigMapRules() []models.AnnotationRule {
+	return []models.AnnotationRule{
+		// Tier A - AUTO
+		{
+			Name:        "SSL Redirect",
+			Pattern:     "ssl-redirect",
+			RiskLevel:   models.RiskAuto,
+			Description: "Global default for redirecting HTTP to HTTPS",
+			MigrationNote: "Configure an HTTP listener with a RequestRedirect filter to HTTPS on each " +
+				"Gateway, or per HTTPRoute.",
+			SourceURL: configMapDocsURL + "#ssl-redirect",
+		},
+		{
+			Name:          "Force SSL Redirect",
+			Pattern:       "force-ssl-redirect",
+			RiskLevel:     models.RiskAuto,
+			Description:   "Global HTTPS redirect even without TLS on the Ingress",
+			MigrationNote: "Use RequestRedirect filters on the Gateway's HTTP listener.",
+			SourceURL:     configMapDocsURL + "#force-ssl-redirect",
+		},
+
+		// Tier B - MANUAL
+		{
+			Name:        "Use Forwarded Headers",
+			Pattern:     "use-forwarded-headers",
+			RiskLevel:   models.RiskManual,
+			Description: "Trust X-Forwarded-* headers from an upstream proxy or load balancer",
+			MigrationNote: "Gateway implementations handle client IP and forwarded headers differently. " +
+				"Verify trusted-proxy settings so backends keep seeing the real client IP and scheme.",
+			SourceURL: configMapDocsURL + "#use-forwarded-headers",
+		},
+		{
+			Name:        "Compute Full Forwarded For",
+			Pattern:     "compute-full-forwarded-for",
+			RiskLevel:   models.RiskManual,
+			Description: "Append the remote address to X-Forwarded-For instead of replacing it",
+			MigrationNote: "Check how your Gateway builds X-Forwarded-For; applications parsing the " +
+				"header may see a different chain.",
+			SourceURL: configMapDocsURL + "#compute-full-forwarded-for",
+		},
+		{
+			Name:          "Forwarded For Header",
+			Pattern:       "forwarded-for-header",
+			RiskLevel:     models.RiskManual,
+			Description:   "Custom header used to identify the client IP",
+			MigrationNote: "Configure the equivalent client IP header in your Gateway implementation, if supported.",
+			SourceURL:     configMapDocsURL + "#forwarded-for-header",
+		},
+		{
+			Name:          "Proxy Real IP CIDR",
+			Pattern:       "proxy-real-ip-cidr",
+			RiskLevel:     models.RiskManual,
+			Description:   "Trusted address ranges for real client IP resolution",
+			MigrationNote: "Recreate trusted CIDRs in the Gateway implementation's client IP policy.",
+			SourceURL:     configMapDocsURL + "#proxy-real-ip-cidr",
+		},
+		{
+			Name:        "Use Proxy Protocol",
+			Pattern:     "use-proxy-protocol",
+			RiskLevel:   models.RiskManual,
+			Description: "Accept PROXY protocol from the load balancer",
+			MigrationNote: "PROXY protocol is implementation-specific. Enable it on both the Gateway and the " +
+				"cloud load balancer at the same time during cutover.",
+			SourceURL: configMapDocsURL + "#use-proxy-protocol",
+		},
+		{
+			Name:        "HSTS",
+			Pattern:     "hsts",
+			RiskLevel:   models.RiskManual,
+			Description: "Strict-Transport-Security header on all HTTPS responses",
+			MigrationNote: "Gateway API does not add HSTS by default. Add it with a ResponseHeaderModifier " +
+				"filter or implementation policy to avoid silently dropping the header.",
+			SourceURL: configMapDocsURL + "#hsts",
+		},
+		{
+			Name:          "HSTS Max Age",
+			Pattern:       "hsts-max-age",
+			RiskLevel:     models.RiskManual,
+			Description:   "max-age of the Strict-Transport-Security header",
+			MigrationNote: "Carry the value over into the ResponseHeaderModifier that sets HSTS.",
+			SourceURL:     configMapDocsURL + "#hsts-max-age",
+		},
+		{
+			Name:          "HSTS Include Subdomains",
+			Pattern:       "hsts-include-subdomains",
+			RiskLevel:     models.RiskManual,
+			Description:   "includeSubDomains directive of the Strict-Transport-Security header",
+			MigrationNote: "Carry the directive over into the ResponseHeaderModifier that sets HSTS.",
+			SourceURL:     configMapDocsURL + "#hsts-include-subdomains",
+		},
+		{
+			Name:          "HSTS Preload",
+			Pattern:       "hsts-preload",
+			RiskLevel:     models.RiskManual,
+			Description:   "preload directive of the Strict-Transport-Security header",
+			MigrationNote: "Carry the directive over into the ResponseHeaderModifier that sets HSTS.",
+			SourceURL:     configMapDocsURL + "#hsts-preload",
+		},
+		{
+			Name:        "OpenTracing",
+			Pattern:     "enable-opentracing",
+			RiskLevel:   models.RiskManual,
+			Description: "Distributed tracing from the controller",
+			MigrationNote: "Tracing is configured per Gateway implementation. Plan the equivalent tracing " +
+				"setup so traces do not lose the ingress span.",
+			SourceURL: configMapDocsURL + "#enable-opentracing",
+		},
+		{
+			Name:          "OpenTelemetry",
+			Pattern:       "enable-opentelemetry",
+			RiskLevel:     models.RiskManual,
+			Description:   "OpenTelemetry tracing from the controller",
+			MigrationNote: "Configure OpenTelemetry in your Gateway implementation; most support OTLP export.",
+			SourceURL:     configMapDocsURL + "#enable-opentelemetry",
+		},
+		{
+			Name:        "Upstream Log Format",
+			Pattern:     "log-format-upstream",
+			RiskLevel:   models.RiskManual,
+			Description: "Custom access log format",
+			MigrationNote: "Access log formats and variables differ per implementation. Dashboards and log " +
+				"parsers relying on this format must be updated.",
+			SourceURL: configMapDocsURL + "#log-format-upstream",
+		},
+		{
+			Name:          "JSON Log Escaping",
+			Pattern:       "log-format-escape-json",
+			RiskLevel:     models.RiskManual,
+			Description:   "JSON escaping in access logs",
+			MigrationNote: "Review together with log-format-upstream when rebuilding access logging.",
+			SourceURL:     configMapDocsURL + "#log-format-escape-json",
+		},
+		{
+			Name:        "SSL Protocols",
+			Pattern:     "ssl-protocols",
+			RiskLevel:   models.RiskManual,
+			Description: "Allowed TLS protocol versions",
+			MigrationNote: "Gateway API exposes TLS options per listener and implementation. Verify the " +
+				"minimum TLS version matches to avoid breaking old clients or failing compliance.",
+			SourceURL: configMapDocsURL + "#ssl-protocols",
+		},
+		{
+			Name:          "SSL Ciphers",
+			Pattern:       "ssl-ciphers",
+			RiskLevel:     models.RiskManual,
+			Description:   "Allowed TLS cipher suites",
+			MigrationNote: "Cipher configuration is implementation-specific; reproduce it via listener TLS options.",
+			SourceURL:     configMapDocsURL + "#ssl-ciphers",
+		},
+		{
+			Name:        "Proxy Body Size",
+			Pattern:     "proxy-body-size",
+			RiskLevel:   models.RiskManual,
+			Description: "Global maximum client request body size",
+			MigrationNote: "Every Ingress inherits this limit. Configure the equivalent global or per-route " +
+				"limit in your Gateway implementation.",
+			SourceURL: configMapDocsURL + "#proxy-body-size",
+		},
+		{
+			Name:          "Proxy Read Timeout",
+			Pattern:       "proxy-read-timeout",
+			RiskLevel:     models.RiskManual,
+			Description:   "Global timeout for reading backend responses",
+			MigrationNote: "Set HTTPRoute timeouts (GEP-1742) or implementation policies for all routes.",
+			SourceURL:     configMapDocsURL + "#proxy-read-timeout",
+		},
+		{
+			Name:          "Proxy Send Timeout",
+			Pattern:       "proxy-send-timeout",
+			RiskLevel:     models.RiskManual,
+			Description:   "Global timeout for sending requests to backends",
+			MigrationNote: "Set HTTPRoute timeouts (GEP-1742) or implementation policies for all routes.",
+			SourceURL:     configMapDocsURL + "#proxy-send-timeout",
+		},
+		{
+			Name:        "Custom HTTP Errors",
+			Pattern:     "custom-http-errors",
+			RiskLevel:   models.RiskManual,
+			Description: "Intercept listed status codes and serve them from the default backend",
+			MigrationNote: "Custom error pages have no Gateway API equivalent; use implementation-specific " +
+				"policies or handle errors in the application.",
+			SourceURL: configMapDocsURL + "#custom-http-errors",
+		},
+		{
+			Name:          "Gzip",
+			Pattern:       "use-gzip",
+			RiskLevel:     models.RiskManual,
+			Description:   "Response compression with gzip",
+			MigrationNote: "Compression is implementation-specific. Enable it in the Gateway or the application.",
+			SourceURL:     configMapDocsURL + "#use-gzip",
+		},
+		{
+			Name:          "Brotli",
+			Pattern:       "enable-brotli",
+			RiskLevel:     models.RiskManual,
+			Description:   "Response compression with brotli",
+			MigrationNote: "Compression is implementation-specific. Enable it in the Gateway or the application.",
+			SourceURL:     configMapDocsURL + "#enable-brotli",
+		},
+		{
+			Name:        "Global Auth URL",
+			Pattern:     "global-auth-url",
+			RiskLevel:   models.RiskManual,
+			Description: "External authentication applied to every Ingress",
+			MigrationNote: "Attach an external auth policy to every route or Gateway; forgetting one route " +
+				"exposes it without authentication.",
+			SourceURL: configMapDocsURL + "#global-auth-url",
+		},
+		{
+			Name:        "Allowlist Source Range",
+			Pattern:     "whitelist-source-range",
+			RiskLevel:   models.RiskManual,
+			Description: "Global client IP allowlist",
+			MigrationNote: "Reproduce the allowlist with implementation policies or network policies at the " +
+				"load balancer.",
+			SourceURL: configMapDocsURL + "#whitelist-source-range",
+		},
+
+		// Tier C - HIGH_RISK
+		{
+			Name:        "Allow Snippet Annotations",
+			Pattern:     "allow-snippet-annotations",
+			RiskLevel:   models.RiskHigh,
+			Description: "Lets Ingress owners inject raw NGINX configuration via snippet annotations",
+			MigrationNote: "Snippets cannot be migrated automatically. Inventory every snippet annotation in " +
+				"the cluster before migrating; new ones may appear until this is disabled.",
+			SourceURL: configMapDocsURL + "#allow-snippet-annotations",
+		},
+		{
+			Name:        "HTTP Snippet",
+			Pattern:     "http-snippet",
+			RiskLevel:   models.RiskHigh,
+			Description: "Raw NGINX configuration in the http block",
+			MigrationNote: "Global NGINX configuration has no Gateway API equivalent. Review each directive and " +
+				"reimplement it with Gateway policies or infrastructure changes.",
+			SourceURL: configMapDocsURL + "#http-snippet",
+		},
+		{
+			Name:          "Server Snippet",
+			Pattern:       "server-snippet",
+			RiskLevel:     models.RiskHigh,
+			Description:   "Raw NGINX configuration added to every server block",
+			MigrationNote: "Applies to all hosts. Review each directive and reimplement it per Gateway listener.",
+			SourceURL:     configMapDocsURL + "#server-snippet",
+		},
+		{
+			Name:          "Location Snippet",
+			Pattern:       "location-snippet",
+			RiskLevel:     models.RiskHigh,
+			Description:   "Raw NGINX configuration added to every location block",
+			MigrationNote: "Applies to all routes. Review each directive and reimplement it with filters or policies.",
+			SourceURL:     configMapDocsURL + "#location-snippet",
+		},
+		{
+			Name:          "Main Snippet",
+			Pattern:       "main-snippet",
+			RiskLevel:     models.RiskHigh,
+			Description:   "Raw NGINX configuration in the main context",
+			MigrationNote: "Process-level NGINX tuning has no Gateway API equivalent; review with the platform team.",
+			SourceURL:     configMapDocsURL + "#main-snippet",
+		},
+		{
+			Name:          "Stream Snippet",
+			Pattern:       "stream-snippet",
+			RiskLevel:     models.RiskHigh,
+			Description:   "Raw NGINX configuration in the stream block",
+			MigrationNote: "Custom Layer 4 logic must be rebuilt with TCPRoute/UDPRoute or outside the Gateway.",
+			SourceURL:     configMapDocsURL + "#stream-snippet",
+		},
+		{
+			Name:        "ModSecurity",
+			Pattern:     "enable-modsecurity",
+			RiskLevel:   models.RiskHigh,
+			Description: "ModSecurity web application firewall",
+			MigrationNote: "WAF functionality is not part of Gateway API. Plan a replacement WAF (cloud WAF, " +
+				"implementation add-on) before cutover.",
+			SourceURL: configMapDocsURL + "#enable-modsecurity",
+		},
+		{
+			Name:          "OWASP Core Rule Set",
+			Pattern:       "enable-owasp-modsecurity-crs",
+			RiskLevel:     models.RiskHigh,
+			Description:   "OWASP ModSecurity Core Rule Set",
+			MigrationNote: "Replace with an equivalent managed WAF rule set before cutover.",
+			SourceURL:     configMapDocsURL + "#enable-owasp-modsecurity-crs",
+		},
+		{
+			Name:          "Lua Plugins",
+			Pattern:       "plugins",
+			RiskLevel:     models.RiskHigh,
+			Description:   "Custom Lua plugins loaded by the controller",
+			MigrationNote: "Lua plugins are specific to ingress-nginx and must be reimplemented.",
+			SourceURL:     configMapDocsURL + "#plugins",
+		},
+	}
+}
+
+// MatchConfigMapSettings finds all ConfigMap rules that apply to the given
+// controller ConfigMap data. Feature toggles set to "false" are ignored, and
+// so are the settings of a switched-off feature. Toggles that are on by
+// default match when the key is absent.
+func MatchConfigMapSettings(data map[string]string) []models.AnnotationRule {
+	var matchedRules []models.AnnotationRule
+
+	for _, rule := range GetConfigMapRules() {
+		value, exists := data[rule.Pattern]
+		if !exists && !defaultOn[rule.Pattern] {
+			continue
+		}
+		if featureToggles[rule.Pattern] && isSwitchedOff(value) {
+			continue
+		}
+		if toggle, ok := toggledSettings[rule.Pattern]; ok && isSwitchedOff(data[toggle]) {
+			continue
+		}
+		matchedRules = append(matchedRules, rule)
+	}
+
+	return matchedRules
+}
+
+// IsDefaultOn reports whether a ConfigMap feature toggle is on when the
+// ConfigMap does not set it
+func IsDefaultOn(key string) bool {
+	return defaultOn[key]
+}
+
+// isSwitchedOff reports whether a feature toggle value is "false"
+func isSwitchedOff(value string) bool {
+	return strings.EqualFold(strings.TrimSpace(value), "false")
+}
+
+// GetUnknownConfigMapKeys returns ConfigMap keys without a rule, sorted
+func GetUnknownConfigMapKeys(data map[string]string) []string {
+	known := make(map[string]bool)
+	for _, rule := range GetConfigMapRules() {
+		known[rule.Pattern] = true
+	}
+
+	var unknown []string
+	for key := range data {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	return unknown
+}
@@ -0,0 +1,65 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+func TestMatchConfigMapSettings(t *testing.T) {
+	data := map[string]string{
+		"allow-snippet-annotations": "false",
+		"use-forwarded-headers":     "true",
+		"http-snippet":              "map $http_x_tenant $tenant { default none; }",
+		"worker-processes":          "4",
+	}
+
+	matched := MatchConfigMapSettings(data)
+
+	var patterns []string
+	for _, rule := range matched {
+		patterns = append(patterns, rule.Pattern)
+	}
+	want := []string{"use-forwarded-headers", "hsts", "http-snippet"}
+	if !reflect.DeepEqual(patterns, want) {
+		t.Errorf("matched %v, want %v", patterns, want)
+	}
+
+	if risk := GetHighestRiskLevel(matched); risk != models.RiskHigh {
+		t.Errorf("risk = %s, want %s", risk, models.RiskHigh)
+	}
+
+	if unknown := GetUnknownConfigMapKeys(data); !reflect.DeepEqual(unknown, []string{"worker-processes"}) {
+		t.Errorf("unknown keys = %v, want [worker-processes]", unknown)
+	}
+}
+
+func TestMatchConfigMapSettingsSkipsDisabledHSTS(t *testing.T) {
+	disabled := map[string]string{
+		"hsts":                    "False",
+		"hsts-max-age":            "31536000",
+		"hsts-include-subdomains": "true",
+	}
+	if matched := MatchConfigMapSettings(disabled); len(matched) != 0 {
+		t.Errorf("matched %v, want nothing while HSTS is switched off", matched)
+	}
+
+	enabled := map[string]string{"hsts": "true", "hsts-max-age": "31536000"}
+	if matched := MatchConfigMapSettings(enabled); len(matched) != 2 {
+		t.Errorf("matched %v, want hsts and hsts-max-age", matched)
+	}
+}
+
+func TestMatchConfigMapSettingsReportsHSTSOnByDefault(t *testing.T) {
+	for _, data := range []map[string]string{nil, {}} {
+		matched := MatchConfigMapSettings(data)
+		if len(matched) != 1 || matched[0].Pattern != "hsts" {
+			t.Errorf("MatchConfigMapSettings(%v) = %v, want hsts, which is on by default", data, matched)
+		}
+	}
+
+	if matched := MatchConfigMapSettings(map[string]string{"hsts-max-age": "600"}); len(matched) != 2 {
+		t.Errorf("matched %v, want hsts and hsts-max-age while hsts is unset", matched)
+	}
+}
@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+
+	"sigs.k8s.io/yaml"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// RuleFile is the YAML or JSON format of a --rules file. Patterns are
+// regular expressions that must match the whole annotation key. Rules are
+// matched to built-in rules by pattern:
+//
+//   - a rule with the pattern of an existing rule overrides the fields it
+//     sets and keeps the others, unless replace is true
+//   - a rule with a new pattern is added and needs a name and riskLevel;
+//     added rules are matched before the built-in ones
+//   - remove drops the rules with these patterns
+//
+// Files are applied in order, so a later file overrides an earlier one.
+type RuleFile struct {
+	Rules  []RuleOverride `json:"rules,omitempty"`
+	Remove []string       `json:"remove,omitempty"`
+}
+
+// RuleOverride is a rule in a rule file; empty fields are not overridden
+type RuleOverride struct {
+	Pattern       string           `json:"pattern"`
+	Name          string           `json:"name,omitempty"`
+	RiskLevel     models.RiskLevel `json:"riskLevel,omitempty"`
+	Description   string           `json:"description,omitempty"`
+	MigrationNote string           `json:"migrationNote,omitempty"`
+	SourceURL     string           `json:"sourceUrl,omitempty"`
+	// Conditions replace the value conditions of the matching rule
+	Conditions []models.ValueCondition `json:"conditions,omitempty"`
+	// Replace replaces the matching rule instead of merging into it
+	Replace bool `json:"replace,omitempty"`
+}
+
+// activeRules are the annotation rules after applying rule files, nil
+// while only the built-in rules are in use
+var activeRules []models.AnnotationRule
+
+// LoadRuleFiles applies the rule files in paths, in order, over the
+// built-in annotation rules. The result is used by every function of this
+// package until the next call; no paths restores the built-in rules.
+func LoadRuleFiles(paths []string) error {
+	if len(paths) == 0 {
+		activeRules = nil
+		return nil
+	}
+
+	rules := builtinAnnotationRules()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read rules file: %w", err)
+		}
+		var file RuleFile
+		if err := yaml.UnmarshalStrict(data, &file); err != nil {
+			return fmt.Errorf("failed to parse rules file %s: %w", path, err)
+		}
+		rules, err = applyRuleFile(rules, file, path)
+		if err != nil {
+			return fmt.Errorf("invalid rules file %s: %w", path, err)
+		}
+	}
+
+	activeRules = rules
+	return nil
+}
+
+// applyRuleFile merges the rules of file, loaded from source, into rules
+func applyRuleFile(rules []models.AnnotationRule, file RuleFile, source string) ([]models.AnnotationRule, error) {
+	rules = slices.Clone(rules)
+
+	for _, pattern := range file.Remove {
+		i := indexOfPattern(rules, pattern)
+		if i < 0 {
+			return nil, fmt.Errorf("cannot remove %q: no rule has this pattern", pattern)
+		}
+		rules = slices.Delete(rules, i, i+1)
+	}
+
+	var added []models.AnnotationRule
+	for _, override := range file.Rules {
+		if err := validateOverride(override); err != nil {
+			return nil, err
+		}
+
+		i := indexOfPattern(rules, override.Pattern)
+		if i < 0 {
+			if override.Name == "" || override.RiskLevel == "" {
+				return nil, fmt.Errorf("new rule %q needs a name and riskLevel", override.Pattern)
+			}
+			added = append(added, mergeRule(models.AnnotationRule{}, override, source))
+			continue
+		}
+
+		base := rules[i]
+		if override.Replace {
+			if override.Name == "" || override.RiskLevel == "" {
+				return nil, fmt.Errorf("replacement rule %q needs a name and riskLevel", override.Pattern)
+			}
+			base = models.AnnotationRule{}
+		}
+		rules[i] = mergeRule(base, override, source)
+	}
+
+	return append(added, rules...), nil
+}
+
+// validateOverride checks the pattern and risk level of a rule file entry
+func validateOverride(override RuleOverride) error {
+	if override.Pattern == "" {
+		return fmt.Errorf("rule %q has no pattern", override.Name)
+	}
+	if _, err := regexp.Compile(override.Pattern); err != nil {
+		return fmt.Errorf("rule %q has an invalid pattern: %w", override.Pattern, err)
+	}
+	if !validRiskLevel(override.RiskLevel) {
+		return fmt.Errorf("rule %q has invalid riskLevel %q: must be %s, %s or %s",
+			override.Pattern, override.RiskLevel, models.RiskAuto, models.RiskManual, models.RiskHigh)
+	}
+	for _, condition := range override.Conditions {
+		if _, err := regexp.Compile(condition.Value); err != nil {
+			return fmt.Errorf("rule %q has an invalid condition value %q: %w", override.Pattern, condition.Value, err)
+		}
+		if !validRiskLevel(condition.RiskLevel) {
+			return fmt.Errorf("rule %q has a condition with invalid riskLevel %q", override.Pattern, condition.RiskLevel)
+		}
+	}
+	return nil
+}
+
+// validRiskLevel reports whether level is a risk level or empty
+func validRiskLevel(level models.RiskLevel) bool {
+	switch level {
+	case "", models.RiskAuto, models.RiskManual, models.RiskHigh:
+		return true
+	}
+	return false
+}
+
+// mergeRule sets the non-empty fields of override on rule
+func mergeRule(rule models.AnnotationRule, override RuleOverride, source string) models.AnnotationRule {
+	rule.Pattern = override.Pattern
+	if override.Name != "" {
+		rule.Name = override.Name
+	}
+	if override.RiskLevel != "" {
+		rule.RiskLevel = override.RiskLevel
+	}
+	if override.Description != "" {
+		rule.Description = override.Description
+	}
+	if override.MigrationNote != "" {
+		rule.MigrationNote = override.MigrationNote
+	}
+	if override.SourceURL != "" {
+		rule.SourceURL = override.SourceURL
+	}
+	if len(override.Conditions) > 0 {
+		rule.Conditions = override.Conditions
+	}
+	rule.Source = source
+	return rule
+}
+
+// indexOfPattern returns the index of the rule with pattern, or -1
+func indexOfPattern(rules []models.AnnotationRule, pattern string) int {
+	return slices.IndexFunc(rules, func(rule models.AnnotationRule) bool {
+		return rule.Pattern == pattern
+	})
+}
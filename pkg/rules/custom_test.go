@@ -0,0 +1,118 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ingress-migration-analyzer/internal/models"
+)
+
+// writeRuleFile writes a rules file to a temporary directory
+func writeRuleFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadRuleFiles(t *testing.T) {
+	t.Cleanup(func() { LoadRuleFiles(nil) })
+
+	platform := writeRuleFile(t, "platform.yaml", `
+rules:
+- pattern: nginx.ingress.kubernetes.io/enable-cors
+  riskLevel: AUTO
+  migrationNote: Our gateway supports the CORS filter.
+- pattern: nginx.ingress.kubernetes.io/rate-limit
+  replace: true
+  name: Rate Limit
+  riskLevel: HIGH_RISK
+- pattern: nginx.ingress.kubernetes.io/x-team-auth
+  name: Team Auth
+  riskLevel: MANUAL
+  migrationNote: Use the team auth ExtensionRef.
+- pattern: nginx.ingress.kubernetes.io/x-team-region-.*
+  name: Team Regions
+  riskLevel: MANUAL
+- pattern: nginx.ingress.kubernetes.io/backend-protocol
+  conditions:
+  - value: GRPCS?
+    riskLevel: AUTO
+remove:
+- nginx.ingress.kubernetes.io/use-regex
+`)
+	team := writeRuleFile(t, "team.json", `{"rules": [{"pattern": "nginx.ingress.kubernetes.io/x-team-auth", "riskLevel": "AUTO"}]}`)
+
+	if err := LoadRuleFiles([]string{platform, team}); err != nil {
+		t.Fatalf("LoadRuleFiles() error = %v", err)
+	}
+
+	cors := GetRuleByPattern("nginx.ingress.kubernetes.io/enable-cors")
+	if cors == nil || cors.RiskLevel != models.RiskAuto || cors.Name != "CORS Enable" ||
+		cors.MigrationNote != "Our gateway supports the CORS filter." || cors.Source != platform {
+		t.Errorf("enable-cors = %+v, want AUTO with the built-in name, the new note and source %s", cors, platform)
+	}
+	if rateLimit := GetRuleByPattern("nginx.ingress.kubernetes.io/rate-limit"); rateLimit == nil ||
+		rateLimit.RiskLevel != models.RiskHigh || rateLimit.Description != "" {
+		t.Errorf("rate-limit = %+v, want a replacement without the built-in description", rateLimit)
+	}
+	if teamAuth := GetRuleByPattern("nginx.ingress.kubernetes.io/x-team-auth"); teamAuth == nil ||
+		teamAuth.RiskLevel != models.RiskAuto || teamAuth.Name != "Team Auth" || teamAuth.Source != team {
+		t.Errorf("x-team-auth = %+v, want the added rule overridden to AUTO by %s", teamAuth, team)
+	}
+	protocols := MatchAnnotations(map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "GRPC"})
+	if len(protocols) != 1 || protocols[0].RiskLevel != models.RiskAuto || protocols[0].Name != "Backend Protocol" {
+		t.Errorf("backend-protocol GRPC = %+v, want AUTO from the overriding condition", protocols)
+	}
+	if protocols = MatchAnnotations(map[string]string{"nginx.ingress.kubernetes.io/backend-protocol": "FCGI"}); len(protocols) != 1 ||
+		protocols[0].RiskLevel != models.RiskManual {
+		t.Errorf("backend-protocol FCGI = %+v, want the rule risk since the built-in conditions were replaced", protocols)
+	}
+	if rule := GetRuleForAnnotation("nginx.ingress.kubernetes.io/x-team-region-eu"); rule == nil || rule.Name != "Team Regions" {
+		t.Errorf("x-team-region-eu = %+v, want the rule with the regexp pattern", rule)
+	}
+	if GetRuleByPattern("nginx.ingress.kubernetes.io/use-regex") != nil {
+		t.Error("use-regex should have been removed")
+	}
+
+	annotations := map[string]string{
+		"nginx.ingress.kubernetes.io/use-regex":   "true",
+		"nginx.ingress.kubernetes.io/x-team-auth": "on",
+	}
+	if unknown := GetUnknownNginxAnnotations(annotations); len(unknown) != 1 || unknown[0] != "nginx.ingress.kubernetes.io/use-regex" {
+		t.Errorf("GetUnknownNginxAnnotations() = %v, want the removed use-regex only", unknown)
+	}
+
+	if err := LoadRuleFiles(nil); err != nil {
+		t.Fatal(err)
+	}
+	if rule := GetRuleByPattern("nginx.ingress.kubernetes.io/enable-cors"); rule == nil || rule.RiskLevel != models.RiskManual || rule.Source != "" {
+		t.Errorf("enable-cors = %+v, want the built-in rule after reset", rule)
+	}
+}
+
+func TestLoadRuleFilesRejectsInvalidRules(t *testing.T) {
+	t.Cleanup(func() { LoadRuleFiles(nil) })
+
+	tests := map[string]string{
+		"invalid risk level": "rules:\n- pattern: nginx.ingress.kubernetes.io/enable-cors\n  riskLevel: LOW\n",
+		"unknown field":      "rules:\n- pattern: nginx.ingress.kubernetes.io/enable-cors\n  risk: AUTO\n",
+		"new rule unnamed":   "rules:\n- pattern: nginx.ingress.kubernetes.io/x-new\n  riskLevel: AUTO\n",
+		"invalid pattern":    "rules:\n- pattern: 'nginx.ingress.kubernetes.io/(x'\n  name: X\n  riskLevel: AUTO\n",
+		"remove unknown":     "remove:\n- nginx.ingress.kubernetes.io/x-missing\n",
+		"invalid condition":  "rules:\n- pattern: nginx.ingress.kubernetes.io/enable-cors\n  conditions:\n  - value: '(x'\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := writeRuleFile(t, "rules.yaml", content)
+			err := LoadRuleFiles([]string{path})
+			if err == nil || !strings.Contains(err.Error(), path) {
+				t.Errorf("LoadRuleFiles() error = %v, want an error naming %s", err, path)
+			}
+		})
+	}
+}